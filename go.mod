@@ -6,13 +6,13 @@ toolchain go1.24.5
 
 require (
 	fyne.io/fyne/v2 v2.6.2
+	fyne.io/systray v1.11.0
 	github.com/google/uuid v1.6.0
 	gorm.io/driver/sqlite v1.6.0
 	gorm.io/gorm v1.30.1
 )
 
 require (
-	fyne.io/systray v1.11.0 // indirect
 	github.com/BurntSushi/toml v1.5.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/fredbi/uri v1.1.0 // indirect
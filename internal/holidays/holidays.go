@@ -0,0 +1,95 @@
+// Package holidays provides the Brazilian national holiday calendar
+package holidays
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+//go:embed br.json
+var brData []byte
+
+// Holiday is a national non-working day
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+type calendar struct {
+	Fixed []struct {
+		Month int    `json:"month"`
+		Day   int    `json:"day"`
+		Name  string `json:"name"`
+		Since int    `json:"since"`
+	} `json:"fixed"`
+	Easter []struct {
+		Offset int    `json:"offset"`
+		Name   string `json:"name"`
+	} `json:"easter"`
+}
+
+var national calendar
+
+func init() {
+	if err := json.Unmarshal(brData, &national); err != nil {
+		panic("holidays: calendário nacional inválido: " + err.Error())
+	}
+}
+
+// National returns the Brazilian national holidays of year, in date order.
+// Carnaval and Corpus Christi are included as they are observed by most employers
+func National(year int, loc *time.Location) []Holiday {
+	var list []Holiday
+
+	for _, f := range national.Fixed {
+		if f.Since > year {
+			continue
+		}
+		list = append(list, Holiday{
+			Date: time.Date(year, time.Month(f.Month), f.Day, 0, 0, 0, 0, loc),
+			Name: f.Name,
+		})
+	}
+
+	easter := Easter(year, loc)
+	for _, e := range national.Easter {
+		list = append(list, Holiday{Date: easter.AddDate(0, 0, e.Offset), Name: e.Name})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+	return list
+}
+
+// Between returns the national holidays in [from, to)
+func Between(from, to time.Time) []Holiday {
+	var list []Holiday
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, h := range National(year, from.Location()) {
+			if !h.Date.Before(from) && h.Date.Before(to) {
+				list = append(list, h)
+			}
+		}
+	}
+	return list
+}
+
+// Easter returns Easter Sunday of year using the anonymous Gregorian algorithm
+func Easter(year int, loc *time.Location) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,273 @@
+// Package ical reads and writes the subset of iCalendar (RFC 5545) used to exchange
+// calendar events with the attendance tracking application
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	layoutDate     = "20060102"
+	layoutDateTime = "20060102T150405"
+)
+
+// ErrNoCalendar is returned when the input has no VCALENDAR component
+var ErrNoCalendar = errors.New("arquivo não contém um calendário iCalendar")
+
+// Event is a VEVENT component. For all-day events End is exclusive, as in
+// the iCalendar format, so a single day event ends at midnight of the next day
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Categories  []string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	// Stamp is the DTSTAMP of the event, the moment its description was written
+	Stamp time.Time
+	// Modified and Sequence are the LAST-MODIFIED and SEQUENCE of the event,
+	// which tell a calendar that an event with a known UID has changed
+	Modified time.Time
+	Sequence int
+	// Cancelled is set by STATUS:CANCELLED
+	Cancelled bool
+	// Rule and Exceptions are the RRULE and EXDATE of a recurring event.
+	// RecurrenceID is set on an event that overrides a single occurrence of
+	// the recurring event with the same UID
+	Rule         *Rule
+	Exceptions   []time.Time
+	RecurrenceID time.Time
+}
+
+// Days returns the calendar days covered by the event, in the event's location
+func (e *Event) Days() []time.Time {
+	y, m, d := e.Start.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, e.Start.Location())
+
+	end := e.End
+	if end.IsZero() || !end.After(e.Start) {
+		return []time.Time{day}
+	}
+
+	var days []time.Time
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// Parse reads every VEVENT of an iCalendar stream. Recurring events are
+// returned once, as written, and Expand lists their occurrences
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events     []Event
+		current    *Event
+		inCalendar bool
+		depth      int
+	)
+
+	for n, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			inCalendar = true
+			continue
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{}
+			depth = 0
+			continue
+		case name == "BEGIN" && current != nil:
+			// nested components such as VALARM carry properties of their own
+			depth++
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT") && current != nil:
+			if current.End.IsZero() && current.AllDay {
+				current.End = current.Start.AddDate(0, 0, 1)
+			}
+			events = append(events, *current)
+			current = nil
+			continue
+		case name == "END" && current != nil && depth > 0:
+			depth--
+			continue
+		}
+
+		if current == nil || depth > 0 {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			current.UID = value
+		case "SUMMARY":
+			current.Summary = unescape(value)
+		case "DESCRIPTION":
+			current.Description = unescape(value)
+		case "LOCATION":
+			current.Location = unescape(value)
+		case "CATEGORIES":
+			for _, c := range splitList(value) {
+				current.Categories = append(current.Categories, unescape(c))
+			}
+		case "DTSTART":
+			t, allDay, err := parseTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: DTSTART inválido: %w", n+1, err)
+			}
+			current.Start, current.AllDay = t, allDay
+		case "DTSTAMP":
+			if t, _, err := parseTime(value, params); err == nil {
+				current.Stamp = t
+			}
+		case "LAST-MODIFIED":
+			if t, _, err := parseTime(value, params); err == nil {
+				current.Modified = t
+			}
+		case "SEQUENCE":
+			if seq, err := strconv.Atoi(value); err == nil {
+				current.Sequence = seq
+			}
+		case "STATUS":
+			current.Cancelled = strings.EqualFold(value, "CANCELLED")
+		case "RRULE":
+			rule, err := parseRule(value)
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: RRULE inválido: %w", n+1, err)
+			}
+			current.Rule = rule
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				t, _, err := parseTime(v, params)
+				if err != nil {
+					return nil, fmt.Errorf("linha %d: EXDATE inválido: %w", n+1, err)
+				}
+				current.Exceptions = append(current.Exceptions, t)
+			}
+		case "RECURRENCE-ID":
+			t, _, err := parseTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: RECURRENCE-ID inválido: %w", n+1, err)
+			}
+			current.RecurrenceID = t
+		case "DTEND":
+			t, _, err := parseTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: DTEND inválido: %w", n+1, err)
+			}
+			current.End = t
+		}
+	}
+
+	if !inCalendar {
+		return nil, ErrNoCalendar
+	}
+	return events, nil
+}
+
+// unfold joins continuation lines, which start with a space or a tab
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler calendário: %w", err)
+	}
+	return lines, nil
+}
+
+// splitProperty splits "NAME;PARAM=VALUE:value" honoring quoted parameter values
+func splitProperty(line string) (name string, params map[string]string, value string, ok bool) {
+	inQuotes := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuotes = !inQuotes
+		}
+		if c == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = map[string]string{}
+	for _, p := range parts[1:] {
+		if k, v, found := strings.Cut(p, "="); found {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseTime reads a DATE or DATE-TIME value, resolving its TZID parameter
+func parseTime(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(layoutDate) {
+		t, err := time.ParseInLocation(layoutDate, value, time.Local)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.ParseInLocation(layoutDateTime, strings.TrimSuffix(value, "Z"), time.UTC)
+		return t, false, err
+	}
+
+	loc := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(layoutDateTime, value, loc)
+	return t, false, err
+}
+
+// splitList splits a comma separated value, ignoring escaped commas
+func splitList(value string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, value[start:])
+}
+
+// unescape decodes the TEXT escapes of RFC 5545
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
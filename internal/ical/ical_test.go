@@ -0,0 +1,178 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useSaoPaulo runs the test with America/Sao_Paulo as the local time zone,
+// the one floating and DATE values are read in
+func useSaoPaulo(t *testing.T) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("fuso horário indisponível: %v", err)
+	}
+
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+	return loc
+}
+
+// calendar wraps VEVENT lines in a VCALENDAR with CRLF line endings
+func calendar(lines ...string) string {
+	return strings.Join(append(append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...), "END:VCALENDAR", ""), "\r\n")
+}
+
+func TestParse(t *testing.T) {
+	loc := useSaoPaulo(t)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("fuso horário indisponível: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  Event
+	}{
+		{
+			name: "folded lines are joined",
+			input: calendar("BEGIN:VEVENT", "UID:1",
+				"SUMMARY:Escritório ", " CT e", "\t AG",
+				"DTSTART:20260310T120000Z", "END:VEVENT"),
+			want: Event{UID: "1", Summary: "Escritório CT e AG", Start: time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "escaped text and categories",
+			input: calendar("BEGIN:VEVENT", "UID:2",
+				`SUMMARY:Reunião\, sala 3\; andar 2`, `DESCRIPTION:linha 1\nlinha 2 \\ fim`,
+				`CATEGORIES:Presencial,Área\, CT`, "DTSTART:20260310T120000Z", "END:VEVENT"),
+			want: Event{UID: "2", Summary: "Reunião, sala 3; andar 2", Description: "linha 1\nlinha 2 \\ fim",
+				Categories: []string{"Presencial", "Área, CT"}, Start: time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "TZID start and floating end",
+			input: calendar("BEGIN:VEVENT", "UID:3",
+				`DTSTART;TZID="America/New_York":20260310T090000`, "DTEND:20260310T120000", "END:VEVENT"),
+			want: Event{UID: "3", Start: time.Date(2026, time.March, 10, 9, 0, 0, 0, newYork), End: time.Date(2026, time.March, 10, 12, 0, 0, 0, loc)},
+		},
+		{
+			name:  "all-day event without DTEND lasts one day",
+			input: calendar("BEGIN:VEVENT", "UID:4", "DTSTART;VALUE=DATE:20260310", "END:VEVENT"),
+			want: Event{UID: "4", AllDay: true,
+				Start: time.Date(2026, time.March, 10, 0, 0, 0, 0, loc), End: time.Date(2026, time.March, 11, 0, 0, 0, 0, loc)},
+		},
+		{
+			name: "alarm properties stay in the alarm",
+			input: calendar("BEGIN:VEVENT", "UID:5", "SUMMARY:Escritório", "DTSTART:20260310",
+				"BEGIN:VALARM", "DESCRIPTION:Lembrete", "SUMMARY:Alarme", "END:VALARM", "END:VEVENT"),
+			want: Event{UID: "5", Summary: "Escritório", AllDay: true,
+				Start: time.Date(2026, time.March, 10, 0, 0, 0, 0, loc), End: time.Date(2026, time.March, 11, 0, 0, 0, 0, loc)},
+		},
+		{
+			name: "revision and status",
+			input: calendar("BEGIN:VEVENT", "UID:6", "DTSTART:20260310T120000Z", "DTSTAMP:20260311T080000Z",
+				"LAST-MODIFIED:20260310T200000Z", "SEQUENCE:3", "STATUS:CANCELLED", "END:VEVENT"),
+			want: Event{UID: "6", Start: time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC),
+				Stamp: time.Date(2026, time.March, 11, 8, 0, 0, 0, time.UTC), Modified: time.Date(2026, time.March, 10, 20, 0, 0, 0, time.UTC),
+				Sequence: 3, Cancelled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("events = %+v, want one", events)
+			}
+			if got := events[0]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("event = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+		// wantText is part of the message of errors without a sentinel
+		wantText string
+	}{
+		{"no calendar", "BEGIN:VEVENT\r\nEND:VEVENT\r\n", ErrNoCalendar, ""},
+		{"invalid start", calendar("BEGIN:VEVENT", "DTSTART:amanhã", "END:VEVENT"), nil, "linha 4: DTSTART inválido"},
+		{"invalid rule", calendar("BEGIN:VEVENT", "DTSTART:20260310", "RRULE:FREQ=DAILY;COUNT=0", "END:VEVENT"), nil, "linha 5: RRULE inválido"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatal("Parse succeeded, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("Parse error = %q, want it to contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	loc := useSaoPaulo(t)
+
+	events := []Event{
+		{
+			UID:         "2026-03-10-0@app",
+			Summary:     "Presencial - CT",
+			Description: "reunião com o time; revisão, planejamento \\ e uma observação longa o bastante para ser dobrada em várias linhas",
+			Location:    "CT",
+			Categories:  []string{"Presencial", "Área, CT"},
+			Start:       time.Date(2026, time.March, 10, 0, 0, 0, 0, loc),
+			End:         time.Date(2026, time.March, 11, 0, 0, 0, 0, loc),
+			AllDay:      true,
+			Stamp:       time.Date(2026, time.March, 12, 8, 0, 0, 0, time.UTC),
+			Modified:    time.Date(2026, time.March, 11, 18, 30, 0, 0, time.UTC),
+			Sequence:    2,
+		},
+		{
+			UID:       "2026-03-11-0@app",
+			Summary:   "Remoto",
+			Start:     time.Date(2026, time.March, 11, 9, 0, 0, 0, time.UTC),
+			End:       time.Date(2026, time.March, 11, 18, 0, 0, 0, time.UTC),
+			Cancelled: true,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "-//Teste//PT", events); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Errorf("events = %+v, want %+v", got, events)
+	}
+}
@@ -0,0 +1,246 @@
+package ical
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxOccurrences caps the occurrences generated for a single recurring event
+const maxOccurrences = 5000
+
+// Recurrence frequencies
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Rule is the RRULE of a recurring event. Daily and weekly rules may be
+// limited to some weekdays, monthly and yearly rules repeat the day of the
+// first occurrence. A zero Until and Count leave the series without an end
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Until    time.Time
+	Count    int
+	// unsupported marks rules with parts this package does not expand, whose
+	// events keep only their first occurrence
+	unsupported bool
+}
+
+// parseRule reads an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"
+func parseRule(value string) (*Rule, error) {
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		name, v, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(v)
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("INTERVAL inválido: %q", v)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("COUNT inválido: %q", v)
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, allDay, err := parseTime(v, nil)
+			if err != nil {
+				return nil, fmt.Errorf("UNTIL inválido: %w", err)
+			}
+			if allDay {
+				// a DATE until includes the whole day
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			rule.Until = t
+		case "BYDAY":
+			for _, d := range strings.Split(v, ",") {
+				day, ok := weekdays[strings.ToUpper(d)]
+				if !ok {
+					// ordinal weekdays such as 2MO select days within a month
+					rule.unsupported = true
+					continue
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST", "":
+		default:
+			rule.unsupported = true
+		}
+	}
+
+	switch rule.Freq {
+	case FreqDaily, FreqWeekly:
+	case FreqMonthly, FreqYearly:
+		rule.unsupported = rule.unsupported || len(rule.ByDay) > 0
+	default:
+		rule.unsupported = true
+	}
+	return rule, nil
+}
+
+// Expand returns the events with every recurring series replaced by its
+// occurrences. Occurrences listed in EXDATE are dropped and the ones named by
+// the RECURRENCE-ID of another event with the same UID give way to that
+// event. Series without UNTIL or COUNT repeat until limit. Cancelled events,
+// series and overrides are dropped, a cancelled override taking its
+// occurrence along
+func Expand(events []Event, limit time.Time) []Event {
+	overridden := map[string][]time.Time{}
+	for _, e := range events {
+		if !e.RecurrenceID.IsZero() {
+			overridden[e.UID] = append(overridden[e.UID], e.RecurrenceID)
+		}
+	}
+
+	var expanded []Event
+	for _, e := range events {
+		if e.Cancelled {
+			continue
+		}
+		if e.Rule == nil || !e.RecurrenceID.IsZero() {
+			expanded = append(expanded, e)
+			continue
+		}
+
+		for _, start := range e.occurrences(limit) {
+			if e.matchesAny(start, e.Exceptions) || e.matchesAny(start, overridden[e.UID]) {
+				continue
+			}
+
+			o := e
+			o.Rule, o.Exceptions = nil, nil
+			o.Start, o.End = start, e.shiftedEnd(start)
+			expanded = append(expanded, o)
+		}
+	}
+	return expanded
+}
+
+// occurrences returns the start of every occurrence of a recurring event,
+// before limit when the rule has no end of its own
+func (e *Event) occurrences(limit time.Time) []time.Time {
+	r := e.Rule
+	if r.unsupported {
+		return []time.Time{e.Start}
+	}
+
+	ended := func(t time.Time) bool {
+		if !r.Until.IsZero() {
+			return t.After(r.Until)
+		}
+		return r.Count == 0 && !t.Before(limit)
+	}
+
+	var starts []time.Time
+	done := func() bool {
+		return len(starts) == maxOccurrences || r.Count > 0 && len(starts) == r.Count
+	}
+
+	for n := 0; !done(); n += r.Interval {
+		base, candidates := r.period(e.Start, n)
+		if ended(base) {
+			break
+		}
+
+		for _, t := range candidates {
+			if ended(t) || done() {
+				return starts
+			}
+			starts = append(starts, t)
+		}
+	}
+	return starts
+}
+
+// period returns the first day of the period n frequency units after start
+// and the occurrences the rule selects in it, in order
+func (r *Rule) period(start time.Time, n int) (time.Time, []time.Time) {
+	switch r.Freq {
+	case FreqDaily:
+		t := start.AddDate(0, 0, n)
+		if len(r.ByDay) > 0 && !slices.Contains(r.ByDay, t.Weekday()) {
+			return t, nil
+		}
+		return t, []time.Time{t}
+	case FreqWeekly:
+		t := start.AddDate(0, 0, 7*n)
+		if len(r.ByDay) == 0 {
+			return t, []time.Time{t}
+		}
+
+		// weeks start on Monday
+		monday := t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+		var days []time.Time
+		for i := range 7 {
+			day := monday.AddDate(0, 0, i)
+			if slices.Contains(r.ByDay, day.Weekday()) && !day.Before(start) {
+				days = append(days, day)
+			}
+		}
+		return monday, days
+	case FreqMonthly, FreqYearly:
+		t := start.AddDate(0, n, 0)
+		if r.Freq == FreqYearly {
+			t = start.AddDate(n, 0, 0)
+		}
+		// months without the day of the first occurrence are skipped
+		if t.Day() != start.Day() {
+			return t, nil
+		}
+		return t, []time.Time{t}
+	}
+	return start, nil
+}
+
+// matchesAny reports whether the occurrence starting at start is one of
+// times. All-day occurrences are compared by day
+func (e *Event) matchesAny(start time.Time, times []time.Time) bool {
+	for _, t := range times {
+		if e.AllDay {
+			t = t.In(start.Location())
+			if t.Year() == start.Year() && t.YearDay() == start.YearDay() {
+				return true
+			}
+		} else if t.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// shiftedEnd returns the end of the occurrence starting at start, keeping
+// the length of the first occurrence
+func (e *Event) shiftedEnd(start time.Time) time.Time {
+	switch {
+	case e.End.IsZero():
+		return time.Time{}
+	case e.AllDay:
+		// whole days survive daylight saving changes
+		days := int(math.Round(e.End.Sub(e.Start).Hours() / 24))
+		return start.AddDate(0, 0, days)
+	default:
+		return start.Add(e.End.Sub(e.Start))
+	}
+}
@@ -0,0 +1,182 @@
+package ical
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpand(t *testing.T) {
+	loc := useSaoPaulo(t)
+	limit := time.Date(2026, time.March, 20, 0, 0, 0, 0, loc)
+
+	// event builds a VEVENT from its properties
+	event := func(props ...string) []string {
+		return append(append([]string{"BEGIN:VEVENT"}, props...), "END:VEVENT")
+	}
+
+	tests := []struct {
+		name   string
+		events [][]string
+		// want lists the occurrences as "start summary", all-day ones by
+		// day and timed ones in UTC
+		want []string
+	}{
+		{
+			name:   "weekly on some weekdays with COUNT",
+			events: [][]string{event("UID:a", "SUMMARY:Escritório", "DTSTART;VALUE=DATE:20260302", "RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4")},
+			want:   []string{"2026-03-02 Escritório", "2026-03-04 Escritório", "2026-03-09 Escritório", "2026-03-11 Escritório"},
+		},
+		{
+			name:   "weekly on weekdays starting midweek",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260304", "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4")},
+			want:   []string{"2026-03-04 E", "2026-03-06 E", "2026-03-09 E", "2026-03-11 E"},
+		},
+		{
+			name:   "every other week",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=3")},
+			want:   []string{"2026-03-02 E", "2026-03-16 E", "2026-03-30 E"},
+		},
+		{
+			name:   "daily on weekdays until a DATE",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260305", "RRULE:FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20260310")},
+			want:   []string{"2026-03-05 E", "2026-03-06 E", "2026-03-09 E", "2026-03-10 E"},
+		},
+		{
+			name:   "UNTIL past the limit is kept",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY;UNTIL=20260330T235959Z")},
+			want:   []string{"2026-03-02 E", "2026-03-09 E", "2026-03-16 E", "2026-03-23 E", "2026-03-30 E"},
+		},
+		{
+			name:   "monthly skips months without the day",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260131", "RRULE:FREQ=MONTHLY;COUNT=3")},
+			want:   []string{"2026-01-31 E", "2026-03-31 E", "2026-05-31 E"},
+		},
+		{
+			name:   "yearly",
+			events: [][]string{event("UID:a", "SUMMARY:Natal", "DTSTART:20241225", "RRULE:FREQ=YEARLY;COUNT=3")},
+			want:   []string{"2024-12-25 Natal", "2025-12-25 Natal", "2026-12-25 Natal"},
+		},
+		{
+			name:   "unsupported rule keeps the first occurrence",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260309", "RRULE:FREQ=MONTHLY;BYDAY=2MO")},
+			want:   []string{"2026-03-09 E"},
+		},
+		{
+			name:   "timed series keeps its local time across daylight saving",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART;TZID=America/New_York:20260302T090000", "DTEND;TZID=America/New_York:20260302T100000", "RRULE:FREQ=WEEKLY;COUNT=2")},
+			want:   []string{"2026-03-02T14:00Z E", "2026-03-09T13:00Z E"},
+		},
+		{
+			name:   "open-ended series stops at the limit",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY")},
+			want:   []string{"2026-03-02 E", "2026-03-09 E", "2026-03-16 E"},
+		},
+		{
+			name: "later events do not extend open-ended series",
+			events: [][]string{
+				event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY"),
+				event("UID:b", "SUMMARY:F", "DTSTART:20270101", "RRULE:FREQ=DAILY;UNTIL=20270102"),
+			},
+			want: []string{"2026-03-02 E", "2026-03-09 E", "2026-03-16 E", "2027-01-01 F", "2027-01-02 F"},
+		},
+		{
+			name:   "EXDATE drops occurrences",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY;COUNT=4", "EXDATE;VALUE=DATE:20260309,20260323")},
+			want:   []string{"2026-03-02 E", "2026-03-16 E"},
+		},
+		{
+			name: "timed EXDATE in another zone",
+			events: [][]string{event("UID:a", "SUMMARY:E", "DTSTART:20260302T120000Z", "RRULE:FREQ=DAILY;COUNT=3",
+				"EXDATE;TZID=America/Sao_Paulo:20260303T090000")},
+			want: []string{"2026-03-02T12:00Z E", "2026-03-04T12:00Z E"},
+		},
+		{
+			name: "override replaces its occurrence",
+			events: [][]string{
+				event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY;COUNT=3"),
+				event("UID:a", "SUMMARY:Movido", "RECURRENCE-ID;VALUE=DATE:20260309", "DTSTART:20260310"),
+			},
+			want: []string{"2026-03-02 E", "2026-03-10 Movido", "2026-03-16 E"},
+		},
+		{
+			name: "override of another series is ignored",
+			events: [][]string{
+				event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY;COUNT=2"),
+				event("UID:b", "SUMMARY:F", "RECURRENCE-ID;VALUE=DATE:20260309", "DTSTART:20260310"),
+			},
+			want: []string{"2026-03-02 E", "2026-03-09 E", "2026-03-10 F"},
+		},
+		{
+			name: "cancelled override drops its occurrence",
+			events: [][]string{
+				event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY;COUNT=3"),
+				event("UID:a", "SUMMARY:E", "RECURRENCE-ID;VALUE=DATE:20260309", "DTSTART:20260309", "STATUS:CANCELLED"),
+			},
+			want: []string{"2026-03-02 E", "2026-03-16 E"},
+		},
+		{
+			name: "cancelled events and series are dropped",
+			events: [][]string{
+				event("UID:a", "SUMMARY:E", "DTSTART:20260302", "RRULE:FREQ=WEEKLY;COUNT=3", "STATUS:CANCELLED"),
+				event("UID:b", "SUMMARY:F", "DTSTART:20260303", "STATUS:CANCELLED"),
+				event("UID:c", "SUMMARY:G", "DTSTART:20260304", "STATUS:CONFIRMED"),
+			},
+			want: []string{"2026-03-04 G"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			for _, e := range tt.events {
+				lines = append(lines, e...)
+			}
+			events, err := Parse(strings.NewReader(calendar(lines...)))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			got := []string{}
+			for _, e := range Expand(events, limit) {
+				if e.Rule != nil || e.Exceptions != nil {
+					t.Errorf("occurrence %v keeps its rule or exceptions", e.Start)
+				}
+				start := e.Start.UTC().Format("2006-01-02T15:04Z")
+				if e.AllDay {
+					start = e.Start.Format(time.DateOnly)
+				}
+				got = append(got, start+" "+e.Summary)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("occurrences = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandShiftsEnd(t *testing.T) {
+	loc := useSaoPaulo(t)
+	input := calendar("BEGIN:VEVENT", "UID:a", "DTSTART;VALUE=DATE:20260302", "DTEND;VALUE=DATE:20260304",
+		"RRULE:FREQ=WEEKLY;COUNT=2", "END:VEVENT")
+
+	events, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	occurrences := Expand(events, time.Date(2026, time.April, 1, 0, 0, 0, 0, loc))
+	if len(occurrences) != 2 {
+		t.Fatalf("occurrences = %+v, want two", occurrences)
+	}
+	second := occurrences[1]
+	if want := time.Date(2026, time.March, 11, 0, 0, 0, 0, loc); !second.End.Equal(want) {
+		t.Errorf("end = %v, want %v", second.End, want)
+	}
+	if days := second.Days(); len(days) != 2 {
+		t.Errorf("days = %v, want two", days)
+	}
+}
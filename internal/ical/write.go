@@ -0,0 +1,103 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding
+const maxLineOctets = 75
+
+// Write writes the events as an iCalendar stream with CRLF line endings,
+// folding long lines. prodID identifies the product that wrote the calendar
+func Write(w io.Writer, prodID string, events []Event) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", prodID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		if !e.Stamp.IsZero() {
+			line("DTSTAMP", e.Stamp.UTC().Format(layoutDateTime)+"Z")
+		}
+		if !e.Modified.IsZero() {
+			line("LAST-MODIFIED", e.Modified.UTC().Format(layoutDateTime)+"Z")
+		}
+		line("SEQUENCE", strconv.Itoa(e.Sequence))
+		if e.Cancelled {
+			line("STATUS", "CANCELLED")
+		}
+
+		end := e.End
+		if e.AllDay {
+			if end.IsZero() {
+				end = e.Start.AddDate(0, 0, 1)
+			}
+			line("DTSTART;VALUE=DATE", e.Start.Format(layoutDate))
+			line("DTEND;VALUE=DATE", end.Format(layoutDate))
+		} else {
+			line("DTSTART", e.Start.UTC().Format(layoutDateTime)+"Z")
+			if !end.IsZero() {
+				line("DTEND", end.UTC().Format(layoutDateTime)+"Z")
+			}
+		}
+
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION", escape(e.Location))
+		}
+		if len(e.Categories) > 0 {
+			categories := make([]string, len(e.Categories))
+			for i, c := range e.Categories {
+				categories[i] = escape(c)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("erro ao gravar calendário: %w", err)
+	}
+	return nil
+}
+
+// writeFolded writes a content line, continuing it on lines that start with
+// a space whenever it exceeds maxLineOctets, without splitting a character
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts toward its length
+		limit = maxLineOctets - 1
+	}
+	_, _ = w.WriteString(line + "\r\n")
+}
+
+// escape encodes a TEXT value with the escapes of RFC 5545
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(value)
+}
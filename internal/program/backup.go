@@ -0,0 +1,114 @@
+package program
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// showBackupExport asks for a file and writes the full backup to it
+func (m *MainApp) showBackupExport() {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		filePath := writer.URI().Path()
+		_ = writer.Close()
+
+		if !strings.HasSuffix(filePath, ".json") {
+			filePath += ".json"
+		}
+
+		if err := store.ExportBackup(m.repo, filePath); err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		dialog.ShowInformation("Sucesso", "Backup completo salvo em:\n"+filePath, m.win)
+	}, m.win)
+	save.SetFileName("presencial-backup-" + time.Now().Format("20060102") + ".json")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	save.Show()
+}
+
+// showBackupRestore asks for a backup file, validates it and, after
+// confirmation, replaces every setting and record with its content
+func (m *MainApp) showBackupRestore() {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		filePath := reader.URI().Path()
+		_ = reader.Close()
+
+		b, err := store.ReadBackup(filePath)
+		if err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		m.confirmRestore(b)
+	}, m.win)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
+
+// confirmRestore describes a validated backup and restores it once confirmed
+func (m *MainApp) confirmRestore(b *store.Backup) {
+	msg := fmt.Sprintf("Backup de %s com %d registro(s), %d tipo(s) de resposta, %d feriado(s) e %d modelo(s) de relatório.\n\n"+
+		"Todas as configurações e registros atuais serão substituídos, depois de salvar um backup automático do banco atual. Continuar?",
+		b.CreatedAt.Local().Format(layoutBR+" 15:04"), len(b.Records), len(b.ResponseTypes), len(b.Holidays), len(b.ReportTemplates))
+
+	dialog.ShowConfirm("Restaurar Backup Completo", msg, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		if err := m.restoreBackup(b); err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		m.reloadAll()
+		m.scheduleBackups()
+		dialog.ShowInformation("Sucesso", "Backup restaurado com sucesso", m.win)
+	}, m.win)
+}
+
+// restoreBackup replaces the database content with a backup, saving a
+// snapshot of the current database first
+func (m *MainApp) restoreBackup(b *store.Backup) error {
+	m.backupMu.Lock()
+	defer m.backupMu.Unlock()
+
+	if _, err := store.TakeSnapshot(m.repo, m.snapshotDir(), time.Now()); err != nil {
+		return err
+	}
+	return m.repo.Restore(b)
+}
+
+// reloadAll reloads everything the window shows after the database content
+// was replaced, including the title and the menu
+func (m *MainApp) reloadAll() {
+	if err := m.loadConfigFromDB(); err != nil {
+		dialog.ShowError(err, m.win)
+		return
+	}
+
+	m.win.SetTitle(m.Language.Title)
+	for _, menu := range m.win.MainMenu().Items {
+		for _, item := range menu.Items {
+			if item.Label == calendarMenuLabel {
+				item.Checked = m.AppConfig.CalendarView
+			}
+		}
+	}
+	m.win.MainMenu().Refresh()
+	m.win.SetContent(m.buildMainContent())
+}
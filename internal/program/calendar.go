@@ -0,0 +1,231 @@
+package program
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+const (
+	calendarCellHigh = 34
+
+	// calendarMenuLabel is the menu item toggling the calendar grid
+	calendarMenuLabel = "Exibir Calendário"
+)
+
+var weekdayInitials = []string{"Seg", "Ter", "Qua", "Qui", "Sex", "Sáb", "Dom"}
+
+// buildCalendar returns a grid of the days of the month shown, colored by the
+// response of their record. Tapping a day opens its records
+func (m *MainApp) buildCalendar() fyne.CanvasObject {
+	byDay := map[string][]store.PresenceRecord{}
+	for _, r := range m.records {
+		byDay[r.Day] = append(byDay[r.Day], r)
+	}
+
+	holidays := map[string]string{}
+	for _, h := range m.holidays {
+		holidays[h.Day] = h.Name
+	}
+
+	grid := container.NewGridWithColumns(7)
+	for _, name := range weekdayInitials {
+		grid.Add(widget.NewLabelWithStyle(name, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+	}
+
+	from, to := monthBounds(m.month)
+	for range (int(from.Weekday()) + 6) % 7 {
+		grid.Add(layout.NewSpacer())
+	}
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(store.LayoutISO)
+		grid.Add(m.calendarCell(day, byDay[key], holidays[key]))
+	}
+
+	return grid
+}
+
+// calendarCell draws a single day with the color of its response and the
+// abbreviated area, or the response icon when the record has no area
+func (m *MainApp) calendarCell(day time.Time, records []store.PresenceRecord, holiday string) fyne.CanvasObject {
+	bg := canvas.NewRectangle(color.Transparent)
+	bg.CornerRadius = 4
+	bg.SetMinSize(fyne.NewSize(0, calendarCellHigh))
+
+	detail := ""
+	switch {
+	case len(records) > 0:
+		r := records[len(records)-1]
+		bg.FillColor = m.responseColor(r.Response)
+		if r.Area != "" {
+			detail = abbreviate(r.Area)
+		} else if rt, ok := m.responseType(r.Response); ok {
+			detail = rt.Icon
+		}
+		if len(records) > 1 {
+			detail += " +"
+		}
+	case holiday != "":
+		bg.FillColor = colorHoliday
+		detail = "🎉"
+	case store.IsWeekend(day):
+		bg.FillColor = colorEmpty
+	}
+
+	if store.SameDay(day, time.Now()) {
+		bg.StrokeColor = theme.Color(theme.ColorNamePrimary)
+		bg.StrokeWidth = 2
+	}
+
+	number := canvas.NewText(strconv.Itoa(day.Day()), theme.Color(theme.ColorNameForeground))
+	number.TextSize = 11
+	number.Alignment = fyne.TextAlignCenter
+
+	sub := canvas.NewText(detail, theme.Color(theme.ColorNameForeground))
+	sub.TextSize = 9
+	sub.Alignment = fyne.TextAlignCenter
+
+	btn := widget.NewButton("", func() {
+		m.showDayRecords(day, records)
+	})
+	btn.Importance = widget.LowImportance
+
+	return container.NewStack(bg, btn, container.NewVBox(layout.NewSpacer(), number, sub, layout.NewSpacer()))
+}
+
+// showDayRecords lists the records of day with edit and delete actions, or
+// asks for a new record when the day has none
+func (m *MainApp) showDayRecords(day time.Time, records []store.PresenceRecord) {
+	if len(records) == 0 {
+		if m.checkDay(day) {
+			m.showNewRecordForm(day)
+		}
+		return
+	}
+
+	var pop dialog.Dialog
+	changed := func() {
+		pop.Hide()
+		m.refreshMainContent()
+	}
+
+	list := container.NewVBox()
+	for i := range records {
+		r := &records[i]
+		text := r.Response
+		if r.Area != "" {
+			text += " - " + r.Area
+		}
+		if r.Observation != "" {
+			text += fmt.Sprintf(" (%s)", r.Observation)
+		}
+
+		editBtn := widget.NewButton("✏", func() { m.showRecordEditForm(r, m.win, changed) })
+		deleteBtn := widget.NewButton("🗑", func() { m.confirmRecordDelete(r, m.win, changed) })
+		list.Add(container.NewBorder(nil, nil, nil, container.NewHBox(editBtn, deleteBtn), widget.NewLabel(text)))
+	}
+
+	addBtn := widget.NewButton("➕ Adicionar", func() {
+		pop.Hide()
+		m.showNewRecordForm(day)
+	})
+
+	closeBtn := widget.NewButton("✖ Fechar", func() {
+		pop.Hide()
+	})
+
+	pop = dialog.NewCustomWithoutButtons(day.Format(layoutBR), container.NewVBox(
+		list,
+		container.NewHBox(layout.NewSpacer(), addBtn, closeBtn),
+	), m.win)
+	pop.Resize(fyne.NewSize(width, highPopup))
+	pop.Show()
+}
+
+// showNewRecordForm asks for the response, area and observation of a new record for day
+func (m *MainApp) showNewRecordForm(day time.Time) {
+	areas, err := m.repo.Areas()
+	if err != nil {
+		dialog.ShowError(err, m.win)
+		return
+	}
+
+	record := &store.PresenceRecord{}
+
+	areaSelect := widget.NewSelect(areas, func(selected string) {
+		record.Area = selected
+	})
+	areaSelect.Disable()
+
+	responseSelect := widget.NewSelect(m.responseNames(), func(selected string) {
+		record.Response = selected
+		if rt, ok := m.responseType(selected); ok && rt.RequiresArea {
+			areaSelect.Enable()
+			return
+		}
+		areaSelect.ClearSelected()
+		areaSelect.Disable()
+	})
+
+	observationEntry := widget.NewEntry()
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Resposta", responseSelect),
+		widget.NewFormItem("Área", areaSelect),
+		widget.NewFormItem("Observação", observationEntry),
+	}
+
+	form := dialog.NewForm(fmt.Sprintf("Registrar %s", day.Format(layoutBR)), "💾 Salvar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		rt, found := m.responseType(record.Response)
+		if !found {
+			dialog.ShowInformation("Erro", "Você precisa selecionar uma resposta", m.win)
+			return
+		}
+		if rt.RequiresArea && record.Area == "" {
+			dialog.ShowInformation("Erro", "Você precisa selecionar um local", m.win)
+			return
+		}
+
+		record.Observation = strings.TrimSpace(observationEntry.Text)
+		// Saving from the calendar only refreshes the grid, even for today
+		m.savePresence(record, day, fmt.Sprintf("%s registrado com sucesso.", rt.Name), m.refreshMainContent)
+	}, m.win)
+	form.Resize(fyne.NewSize(width, 0))
+	form.Show()
+}
+
+// responseColor returns the calendar color of a response, taken from its
+// position in the catalog
+func (m *MainApp) responseColor(name string) color.Color {
+	for i, rt := range m.responseTypes {
+		if rt.Name == name {
+			return chartPalette[i%len(chartPalette)]
+		}
+	}
+	return colorEmpty
+}
+
+// abbreviate shortens an area name to at most four characters
+func abbreviate(area string) string {
+	if r := []rune(area); len(r) > 4 {
+		return strings.ToUpper(string(r[:4]))
+	}
+	return strings.ToUpper(area)
+}
@@ -0,0 +1,93 @@
+package program
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// excelCSV is the format Excel opens correctly with a Brazilian locale
+var excelCSV = store.CSVFormat{Delimiter: ';', BOM: true, DateLayout: layoutBR}
+
+var csvDelimiters = []option[rune]{
+	{';', "Ponto e vírgula (;)"},
+	{',', "Vírgula (,)"},
+	{'\t', "Tabulação"},
+}
+
+var csvEncodings = []option[bool]{
+	{true, "UTF-8 com BOM (Excel)"},
+	{false, "UTF-8"},
+}
+
+// showCSVExportForm asks for the delimiter, encoding and date range of a CSV
+// export and then for the file to write
+func (m *MainApp) showCSVExportForm() {
+	format := excelCSV
+
+	delimiterSelect := widget.NewSelect(labels(csvDelimiters), func(selected string) {
+		format.Delimiter = keyOf(csvDelimiters, selected)
+	})
+	delimiterSelect.SetSelected(labelOf(csvDelimiters, format.Delimiter))
+
+	encodingSelect := widget.NewSelect(labels(csvEncodings), func(selected string) {
+		format.BOM = keyOf(csvEncodings, selected)
+	})
+	encodingSelect.SetSelected(labelOf(csvEncodings, format.BOM))
+
+	from, to := monthBounds(m.month)
+	fromEntry := newDayEntry(from)
+	toEntry := newDayEntry(to.AddDate(0, 0, -1))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Delimitador", delimiterSelect),
+		widget.NewFormItem("Codificação", encodingSelect),
+		widget.NewFormItem("De", fromEntry),
+		widget.NewFormItem("Até", toEntry),
+	}
+
+	form := dialog.NewForm("Exportar Dados (CSV)", "💾 Exportar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		from, to := selectedDay(fromEntry), selectedDay(toEntry)
+		if to.Before(from) {
+			dialog.ShowInformation("Erro", "A data final precisa ser igual ou posterior à inicial", m.win)
+			return
+		}
+
+		m.saveCSV(format, from, to)
+	}, m.win)
+	form.Resize(fyne.NewSize(width, 0))
+	form.Show()
+}
+
+// saveCSV asks for the destination file and exports the records of [from, to] to it
+func (m *MainApp) saveCSV(format store.CSVFormat, from, to time.Time) {
+	dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		filePath := writer.URI().Path()
+		_ = writer.Close()
+
+		if !strings.HasSuffix(filePath, ".csv") {
+			filePath += ".csv"
+		}
+
+		count, err := store.ExportCSV(m.repo, filePath, format, from, to)
+		if err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		dialog.ShowInformation("Sucesso", fmt.Sprintf("%d registro(s) exportado(s) com sucesso", count), m.win)
+	}, m.win)
+}
@@ -0,0 +1,249 @@
+package program
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// maxRowErrors is how many row errors the CSV import lists
+const maxRowErrors = 50
+
+var csvImportDelimiters = []option[rune]{
+	{';', "Ponto e vírgula (;)"},
+	{',', "Vírgula (,)"},
+	{'\t', "Tabulação"},
+	{'|', "Barra vertical (|)"},
+}
+
+var csvFieldLabels = []option[string]{
+	{"", "Ignorar"},
+	{store.FieldDate, "Data"},
+	{store.FieldTime, "Hora"},
+	{store.FieldResponse, "Resposta"},
+	{store.FieldArea, "Área"},
+	{store.FieldObservation, "Observação"},
+}
+
+// csvImport holds the state of the CSV import wizard
+type csvImport struct {
+	m         *MainApp
+	win       fyne.Window
+	filePath  string
+	file      *store.CSVFile
+	hasHeader bool
+	mapping   store.CSVMapping
+
+	columns *fyne.Container
+	status  *widget.Label
+	errors  *widget.Label
+	next    *widget.Button
+
+	records []store.PresenceRecord
+}
+
+// showCSVImportWizard reads a CSV file and opens a window to choose its
+// delimiter and map its columns to record fields. The converted records go
+// through the same preview as a JSON import
+func (m *MainApp) showCSVImportWizard(filePath string) error {
+	file, err := store.ReadCSV(filePath, 0)
+	if err != nil {
+		return err
+	}
+	if len(file.Rows) == 0 {
+		return fmt.Errorf("arquivo CSV vazio")
+	}
+
+	w := &csvImport{
+		m:         m,
+		win:       m.app.NewWindow("Importar Dados (CSV)"),
+		filePath:  filePath,
+		file:      file,
+		hasHeader: store.IsHeaderRow(file.Rows[0]),
+		columns:   container.NewVBox(),
+		status:    widget.NewLabel(""),
+		errors:    widget.NewLabel(""),
+	}
+	w.errors.Wrapping = fyne.TextWrapWord
+	w.next = widget.NewButton("➡ Revisar importação", w.confirm)
+
+	delimiterSelect := widget.NewSelect(labels(csvImportDelimiters), func(selected string) {
+		if err := w.reload(keyOf(csvImportDelimiters, selected)); err != nil {
+			dialog.ShowError(err, w.win)
+		}
+	})
+
+	headerCheck := widget.NewCheck("Primeira linha é cabeçalho", func(checked bool) {
+		w.hasHeader = checked
+		w.resetMapping()
+	})
+	headerCheck.SetChecked(w.hasHeader)
+
+	cancelBtn := widget.NewButton("✖ Cancelar", func() {
+		w.win.Close()
+	})
+
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, widget.NewLabel("Delimitador:"), nil, delimiterSelect),
+		headerCheck,
+		widget.NewLabelWithStyle("Colunas", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	body := container.NewVBox(w.columns, widget.NewSeparator(), w.status, w.errors)
+	buttons := container.NewHBox(layout.NewSpacer(), cancelBtn, w.next)
+
+	// Selecting the detected delimiter builds the column mapping
+	delimiterSelect.SetSelected(labelOf(csvImportDelimiters, file.Delimiter))
+	if delimiterSelect.Selected == "" {
+		w.resetMapping()
+	}
+
+	w.win.SetContent(container.NewBorder(top, buttons, nil, nil, container.NewVScroll(body)))
+	w.win.Resize(fyne.NewSize(recordsWidth, recordsHigh))
+	w.win.CenterOnScreen()
+	w.win.Show()
+	return nil
+}
+
+// reload reads the file again with another delimiter
+func (w *csvImport) reload(delimiter rune) error {
+	if delimiter != w.file.Delimiter {
+		file, err := store.ReadCSV(w.filePath, delimiter)
+		if err != nil {
+			return err
+		}
+		w.file = file
+	}
+	w.resetMapping()
+	return nil
+}
+
+// resetMapping maps the columns from their titles and the configured headers
+// and rebuilds the column selects
+func (w *csvImport) resetMapping() {
+	headers, err := w.m.repo.Headers()
+	if err != nil {
+		dialog.ShowError(err, w.win)
+	}
+
+	titles := make([]string, w.columnCount())
+	if w.hasHeader {
+		copy(titles, w.file.Rows[0])
+	}
+	w.mapping = store.DefaultCSVMapping(titles, headers)
+
+	w.columns.RemoveAll()
+	for col := range w.mapping {
+		fieldSelect := widget.NewSelect(labels(csvFieldLabels), nil)
+		fieldSelect.SetSelected(labelOf(csvFieldLabels, w.mapping[col]))
+		fieldSelect.OnChanged = func(selected string) {
+			w.mapping[col] = keyOf(csvFieldLabels, selected)
+			w.parse()
+		}
+
+		w.columns.Add(container.NewGridWithColumns(2, widget.NewLabel(w.columnText(col)), fieldSelect))
+	}
+	w.columns.Refresh()
+	w.parse()
+}
+
+// columnCount returns the number of columns of the widest row
+func (w *csvImport) columnCount() int {
+	n := 0
+	for _, row := range w.file.Rows {
+		n = max(n, len(row))
+	}
+	return n
+}
+
+// columnText names a column by its title, or its position, and a sample value
+func (w *csvImport) columnText(col int) string {
+	name := fmt.Sprintf("Coluna %d", col+1)
+	data := w.file.Rows
+	if w.hasHeader {
+		if title := cell(data[0], col); title != "" {
+			name = title
+		}
+		data = data[1:]
+	}
+
+	for _, row := range data {
+		if sample := cell(row, col); sample != "" {
+			return fmt.Sprintf("%s (ex.: %s)", name, sample)
+		}
+	}
+	return name
+}
+
+// parse converts the data rows with the current mapping and shows the row errors
+func (w *csvImport) parse() {
+	data, lines := w.file.Rows, w.file.Lines
+	if w.hasHeader {
+		data, lines = data[1:], lines[1:]
+	}
+
+	records, errs, err := store.ParseCSVRecords(data, lines, w.mapping)
+	w.records = records
+	if err != nil {
+		w.status.SetText("⚠️ " + err.Error())
+		w.errors.SetText("")
+		w.next.Disable()
+		return
+	}
+
+	w.status.SetText(fmt.Sprintf("%d linha(s) válida(s), %d com erro", len(w.records), len(errs)))
+
+	messages := make([]string, 0, min(len(errs), maxRowErrors)+1)
+	for i, e := range errs {
+		if i == maxRowErrors {
+			messages = append(messages, fmt.Sprintf("... e mais %d erro(s)", len(errs)-maxRowErrors))
+			break
+		}
+		messages = append(messages, "⚠️ "+e.Error())
+	}
+	w.errors.SetText(strings.Join(messages, "\n"))
+
+	if len(w.records) == 0 {
+		w.next.Disable()
+	} else {
+		w.next.Enable()
+	}
+}
+
+// confirm hands the valid rows to the import preview, asking first when some rows are skipped
+func (w *csvImport) confirm() {
+	preview := func() {
+		if err := w.m.showImportPreview(w.records); err != nil {
+			dialog.ShowError(err, w.win)
+			return
+		}
+		w.win.Close()
+	}
+
+	if w.errors.Text == "" {
+		preview()
+		return
+	}
+
+	dialog.ShowConfirm("Importar Dados (CSV)",
+		"Algumas linhas têm erros e serão ignoradas. Continuar apenas com as linhas válidas?",
+		func(ok bool) {
+			if ok {
+				preview()
+			}
+		}, w.win)
+}
+
+// cell returns the trimmed value of a column, empty when the row is shorter
+func cell(row []string, col int) string {
+	if col >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[col])
+}
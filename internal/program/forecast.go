@@ -0,0 +1,29 @@
+package program
+
+import (
+	"fmt"
+)
+
+// forecastReport describes how the goal of the period shown can still be
+// reached. Periods already over and goals already met need no forecast
+func (m *MainApp) forecastReport() string {
+	f := m.report.Forecast
+	if f == nil {
+		return ""
+	}
+
+	report := fmt.Sprintf("\nPrevisão: faltam %d dia(s) presencial(is) e restam %d dia(s) útil(eis) livre(s)\n",
+		f.Needed, f.Available)
+
+	switch {
+	case !f.Reachable:
+		report += fmt.Sprintf("⚠️ Meta inalcançável: não há dias úteis suficientes até o fim do período (%s)\n", f.PeriodEnd)
+	case f.Needed == f.Available:
+		report += fmt.Sprintf("⚠️ Todos os dias úteis restantes precisam ser presenciais, até %s\n", f.LastDay)
+	case f.Weeks > 1:
+		report += fmt.Sprintf("💡 Ritmo sugerido: %d dia(s) por semana até %s\n", f.PerWeek, f.LastDay)
+	default:
+		report += fmt.Sprintf("💡 Ritmo sugerido: %d dia(s) até %s\n", f.Needed, f.LastDay)
+	}
+	return report
+}
@@ -0,0 +1,52 @@
+package program
+
+import (
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// option pairs a stored value with the text shown for it in a select
+type option[K comparable] struct {
+	key   K
+	label string
+}
+
+var goalModeLabels = []option[store.GoalMode]{
+	{store.GoalDays, "Dias presenciais"},
+	{store.GoalPercent, "% dos dias úteis"},
+}
+
+var goalPeriodLabels = []option[store.GoalPeriod]{
+	{store.PeriodWeek, "Semana"},
+	{store.PeriodMonth, "Mês"},
+	{store.PeriodQuarter, "Trimestre"},
+	{store.PeriodYear, "Ano"},
+}
+
+// labels returns the texts of opts in order
+func labels[K comparable](opts []option[K]) []string {
+	out := make([]string, 0, len(opts))
+	for _, o := range opts {
+		out = append(out, o.label)
+	}
+	return out
+}
+
+// labelOf returns the text shown for key
+func labelOf[K comparable](opts []option[K], key K) string {
+	for _, o := range opts {
+		if o.key == key {
+			return o.label
+		}
+	}
+	return ""
+}
+
+// keyOf returns the value behind label, or the first option when it is unknown
+func keyOf[K comparable](opts []option[K], label string) K {
+	for _, o := range opts {
+		if o.label == label {
+			return o.key
+		}
+	}
+	return opts[0].key
+}
@@ -0,0 +1,129 @@
+package program
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// showHolidayConfigForm lists the holidays of a year and lets the user add
+// company-specific days, remove them or import more from an .ics file
+func (m *MainApp) showHolidayConfigForm(onComplete func()) {
+	year := time.Now().Year()
+	listContainer := container.NewVBox()
+
+	var refreshList func()
+	refreshList = func() {
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+		list, err := m.repo.Holidays(from, from.AddDate(1, 0, 0))
+		if err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		listContainer.Objects = nil
+		for _, h := range list {
+			label := widget.NewLabel(fmt.Sprintf("%s - %s (%s)", h.Date().Format(layoutBR), h.Name, h.Source))
+
+			if h.Source == store.HolidayNational {
+				listContainer.Add(label)
+				continue
+			}
+
+			delBtn := widget.NewButton("🗑", func(id uint) func() {
+				return func() {
+					if err := m.repo.DeleteHoliday(id); err != nil {
+						dialog.ShowError(err, m.win)
+						return
+					}
+					refreshList()
+				}
+			}(h.ID))
+			listContainer.Add(container.NewBorder(nil, nil, nil, delBtn, label))
+		}
+		listContainer.Refresh()
+	}
+
+	var years []string
+	for y := year - 2; y <= year+1; y++ {
+		years = append(years, strconv.Itoa(y))
+	}
+	yearSelect := widget.NewSelect(years, func(selected string) {
+		year, _ = strconv.Atoi(selected)
+		refreshList()
+	})
+
+	dayEntry := newDayEntry(time.Now())
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Nome do feriado")
+
+	addBtn := widget.NewButton("➕ Adicionar", func() {
+		h := &store.Holiday{
+			Day:  selectedDay(dayEntry).Format(store.LayoutISO),
+			Name: strings.TrimSpace(nameEntry.Text),
+		}
+		if err := m.repo.SaveHoliday(h); err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+		nameEntry.SetText("")
+		refreshList()
+	})
+
+	importBtn := widget.NewButton("📅 Importar .ics", func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer func(reader fyne.URIReadCloser) {
+				if err := reader.Close(); err != nil {
+					dialog.ShowError(err, m.win)
+				}
+			}(reader)
+
+			added, err := store.ImportHolidaysICS(m.repo, reader.URI().Path())
+			if err != nil {
+				dialog.ShowError(err, m.win)
+				return
+			}
+
+			dialog.ShowInformation("Sucesso", fmt.Sprintf("%d feriado(s) importado(s)", added), m.win)
+			refreshList()
+		}, m.win)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".ics"}))
+		open.Show()
+	})
+
+	closeBtn := widget.NewButton("✖ Fechar", func() {
+		if err := m.loadConfigFromDB(); err != nil {
+			dialog.ShowError(err, m.win)
+		}
+		onComplete()
+	})
+
+	addRow := container.NewBorder(nil, nil, dayEntry, addBtn, nameEntry)
+	top := container.NewVBox(
+		widget.NewLabelWithStyle("Feriados", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		container.NewBorder(nil, nil, widget.NewLabel("Ano:"), nil, yearSelect),
+	)
+	bottom := container.NewVBox(
+		addRow,
+		container.NewHBox(importBtn, layout.NewSpacer(), closeBtn),
+	)
+
+	yearSelect.SetSelected(strconv.Itoa(year))
+
+	m.win.SetContent(container.NewBorder(top, bottom, nil, nil, container.NewVScroll(listContainer)))
+	m.win.Resize(fyne.NewSize(width, high))
+	m.win.Show()
+}
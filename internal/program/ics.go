@@ -0,0 +1,59 @@
+package program
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// showICSExportForm asks for the date range of a calendar export and then
+// for the file to write
+func (m *MainApp) showICSExportForm() {
+	from, to := monthBounds(m.month)
+	fromEntry := newDayEntry(from)
+	toEntry := newDayEntry(to.AddDate(0, 0, -1))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("De", fromEntry),
+		widget.NewFormItem("Até", toEntry),
+	}
+
+	form := dialog.NewForm("Exportar Dados (ICS)", "💾 Exportar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		from, to := selectedDay(fromEntry), selectedDay(toEntry)
+		if to.Before(from) {
+			dialog.ShowInformation("Erro", "A data final precisa ser igual ou posterior à inicial", m.win)
+			return
+		}
+
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			filePath := writer.URI().Path()
+			_ = writer.Close()
+
+			if !strings.HasSuffix(filePath, ".ics") {
+				filePath += ".ics"
+			}
+
+			count, err := store.ExportICS(m.repo, filePath, from, to)
+			if err != nil {
+				dialog.ShowError(err, m.win)
+				return
+			}
+
+			dialog.ShowInformation("Sucesso", fmt.Sprintf("%d registro(s) exportado(s) com sucesso", count), m.win)
+		}, m.win)
+	}, m.win)
+	form.Resize(fyne.NewSize(width, 0))
+	form.Show()
+}
@@ -0,0 +1,261 @@
+package program
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/ical"
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// maxUnmatched is how many unrecognized event summaries the calendar import lists
+const maxUnmatched = 20
+
+var ruleTargets = []option[string]{
+	{store.RuleResponse, "Resposta"},
+	{store.RuleArea, "Área"},
+}
+
+// icsImport holds the state of the calendar import window
+type icsImport struct {
+	m        *MainApp
+	win      fyne.Window
+	events   []ical.Event
+	rules    []store.CalendarRule
+	areas    []string
+	holidays []store.Holiday
+
+	rows      *fyne.Container
+	status    *widget.Label
+	unmatched *widget.Label
+	next      *widget.Button
+
+	records []store.PresenceRecord
+}
+
+// showICSImportWizard reads an iCalendar file and opens a window to edit the
+// rules that recognize office days in its events. The recognized records go
+// through the same preview as a JSON import
+func (m *MainApp) showICSImportWizard(filePath string) error {
+	// recurring office days are recognized up to today
+	events, err := store.ReadICS(filePath, store.StartOfDay(time.Now()).AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("arquivo de calendário sem eventos")
+	}
+
+	rules, err := m.repo.CalendarRules()
+	if err != nil {
+		return err
+	}
+
+	areas, err := m.repo.Areas()
+	if err != nil {
+		return err
+	}
+
+	from, to := eventSpan(events)
+	holidays, err := m.repo.Holidays(from, to)
+	if err != nil {
+		return err
+	}
+
+	w := &icsImport{
+		m:         m,
+		win:       m.app.NewWindow("Importar Dados (ICS)"),
+		events:    events,
+		rules:     rules,
+		areas:     areas,
+		holidays:  holidays,
+		rows:      container.NewVBox(),
+		status:    widget.NewLabel(""),
+		unmatched: widget.NewLabel(""),
+	}
+	w.unmatched.Wrapping = fyne.TextWrapWord
+	w.next = widget.NewButton("➡ Revisar importação", w.confirm)
+
+	addBtn := widget.NewButton("➕ Adicionar regra", func() {
+		w.rules = append(w.rules, store.CalendarRule{Target: store.RuleResponse})
+		w.rebuild()
+	})
+
+	cancelBtn := widget.NewButton("✖ Cancelar", func() {
+		w.win.Close()
+	})
+
+	top := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("%s: %d evento(s)", filepath.Base(filePath), len(events))),
+		widget.NewLabel("Eventos cujo título ou local contém o padrão recebem a resposta ou a área da primeira regra correspondente.\n"+
+			"Eventos sem regra de resposta correspondente são ignorados."),
+		widget.NewLabelWithStyle("Regras", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	body := container.NewVBox(w.rows, addBtn, widget.NewSeparator(), w.status, w.unmatched)
+	buttons := container.NewHBox(layout.NewSpacer(), cancelBtn, w.next)
+
+	w.rebuild()
+
+	w.win.SetContent(container.NewBorder(top, buttons, nil, nil, container.NewVScroll(body)))
+	w.win.Resize(fyne.NewSize(recordsWidth, recordsHigh))
+	w.win.CenterOnScreen()
+	w.win.Show()
+	return nil
+}
+
+// eventSpan returns the first day the events cover and the day after the last
+func eventSpan(events []ical.Event) (time.Time, time.Time) {
+	var from, to time.Time
+	for _, e := range events {
+		start, end := e.Start.In(time.Local), e.End.In(time.Local)
+		if end.Before(start) {
+			end = start
+		}
+		if from.IsZero() || start.Before(from) {
+			from = start
+		}
+		if end.After(to) {
+			to = end
+		}
+	}
+	return store.StartOfDay(from), store.StartOfDay(to).AddDate(0, 0, 1)
+}
+
+// rebuild recreates the rule rows and matches the events again
+func (w *icsImport) rebuild() {
+	w.rows.RemoveAll()
+	for i := range w.rules {
+		w.rows.Add(w.ruleRow(i))
+	}
+	w.rows.Refresh()
+	w.match()
+}
+
+// ruleRow builds the widgets editing the rule at index i
+func (w *icsImport) ruleRow(i int) fyne.CanvasObject {
+	rule := &w.rules[i]
+
+	valueSelect := widget.NewSelect(w.values(rule.Target), nil)
+	valueSelect.PlaceHolder = "Valor"
+	// a value removed from the catalog since the rule was saved stays selectable
+	if rule.Value != "" && !slices.Contains(valueSelect.Options, rule.Value) {
+		valueSelect.Options = append(valueSelect.Options, rule.Value)
+	}
+	valueSelect.SetSelected(rule.Value)
+	valueSelect.OnChanged = func(selected string) {
+		rule.Value = selected
+		w.match()
+	}
+
+	targetSelect := widget.NewSelect(labels(ruleTargets), nil)
+	targetSelect.SetSelected(labelOf(ruleTargets, rule.Target))
+	targetSelect.OnChanged = func(selected string) {
+		rule.Target = keyOf(ruleTargets, selected)
+		rule.Value = ""
+		valueSelect.Options = w.values(rule.Target)
+		valueSelect.ClearSelected()
+		w.match()
+	}
+
+	patternEntry := widget.NewEntry()
+	patternEntry.SetPlaceHolder("Palavra-chave ou expressão")
+	patternEntry.SetText(rule.Pattern)
+	patternEntry.OnChanged = func(text string) {
+		rule.Pattern = text
+		w.match()
+	}
+
+	regexCheck := widget.NewCheck("Regex", func(checked bool) {
+		rule.Regex = checked
+		w.match()
+	})
+	regexCheck.Checked = rule.Regex
+
+	delBtn := widget.NewButton("🗑", func() {
+		w.rules = append(w.rules[:i], w.rules[i+1:]...)
+		w.rebuild()
+	})
+
+	return container.NewBorder(nil, nil,
+		container.NewHBox(targetSelect, valueSelect),
+		container.NewHBox(regexCheck, delBtn),
+		patternEntry)
+}
+
+// values returns the choices of a rule target: the response names or the areas
+func (w *icsImport) values(target string) []string {
+	if target == store.RuleArea {
+		return w.areas
+	}
+	return w.m.responseNames()
+}
+
+// complete returns the rules with every field filled, the ones the import uses
+func (w *icsImport) complete() []store.CalendarRule {
+	var rules []store.CalendarRule
+	for _, r := range w.rules {
+		if r.Value != "" && strings.TrimSpace(r.Pattern) != "" {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// match applies the complete rules to the events and shows the result
+func (w *icsImport) match() {
+	result, err := store.MatchEvents(w.events, w.complete(), w.holidays)
+	if err != nil {
+		w.records = nil
+		w.status.SetText("⚠️ " + err.Error())
+		w.unmatched.SetText("")
+		w.next.Disable()
+		return
+	}
+
+	w.records = result.Records
+	status := fmt.Sprintf("%d de %d evento(s) reconhecido(s), %d registro(s)",
+		result.Matched, len(w.events), len(w.records))
+	if result.Skipped > 0 {
+		status += fmt.Sprintf(", %d dia(s) em fim de semana ou feriado ignorado(s)", result.Skipped)
+	}
+	w.status.SetText(status)
+
+	unmatched := result.Unmatched
+	if len(unmatched) > maxUnmatched {
+		unmatched = append(unmatched[:maxUnmatched:maxUnmatched], fmt.Sprintf("... e mais %d", len(result.Unmatched)-maxUnmatched))
+	}
+	if len(unmatched) > 0 {
+		w.unmatched.SetText("Não reconhecidos: " + strings.Join(unmatched, "; "))
+	} else {
+		w.unmatched.SetText("")
+	}
+
+	if len(w.records) == 0 {
+		w.next.Disable()
+	} else {
+		w.next.Enable()
+	}
+}
+
+// confirm saves the rules for the next import and hands the recognized records to the import preview
+func (w *icsImport) confirm() {
+	if err := w.m.repo.SaveCalendarRules(w.complete()); err != nil {
+		dialog.ShowError(err, w.win)
+		return
+	}
+
+	if err := w.m.showImportPreview(w.records); err != nil {
+		dialog.ShowError(err, w.win)
+		return
+	}
+	w.win.Close()
+}
@@ -0,0 +1,135 @@
+package program
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+var importActions = []option[store.ImportAction]{
+	{store.ImportKeepLocal, "Manter local"},
+	{store.ImportTakeImported, "Usar importado"},
+	{store.ImportKeepBoth, "Manter ambos"},
+}
+
+var importStatusLabels = []option[store.ImportStatus]{
+	{store.ImportNew, "🆕 Novo"},
+	{store.ImportIdentical, "🟰 Idêntico"},
+	{store.ImportConflict, "⚠️ Conflito"},
+}
+
+// showImportPreview classifies the incoming records against the stored ones
+// and opens a window listing them, where each conflict can be resolved
+// before anything is written
+func (m *MainApp) showImportPreview(records []store.PresenceRecord) error {
+	plan, err := store.PlanImport(m.repo, records)
+	if err != nil {
+		return err
+	}
+
+	win := m.app.NewWindow("Importar Dados")
+
+	list := widget.NewList(
+		func() int { return len(plan) },
+		func() fyne.CanvasObject {
+			status := widget.NewLabel("⚠️ Conflito")
+			action := widget.NewSelect(labels(importActions), nil)
+			return container.NewBorder(nil, nil, status, action, widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			item := &plan[id]
+			row := o.(*fyne.Container)
+			text := row.Objects[0].(*widget.Label)
+			status := row.Objects[1].(*widget.Label)
+			action := row.Objects[2].(*widget.Select)
+
+			status.SetText(labelOf(importStatusLabels, item.Status))
+			text.SetText(importLine(item))
+
+			action.OnChanged = nil
+			if item.Status != store.ImportConflict {
+				action.Hide()
+				return
+			}
+			action.SetSelected(labelOf(importActions, item.Action))
+			action.OnChanged = func(selected string) {
+				item.Action = keyOf(importActions, selected)
+			}
+			action.Show()
+		},
+	)
+
+	conflicts := plan.Count(store.ImportConflict)
+	summary := widget.NewLabel(fmt.Sprintf("%d registro(s) no arquivo: %d novo(s), %d idêntico(s), %d conflito(s)",
+		len(plan), plan.Count(store.ImportNew), plan.Count(store.ImportIdentical), conflicts))
+
+	top := container.NewVBox(summary)
+	if conflicts > 0 {
+		allSelect := widget.NewSelect(labels(importActions), func(selected string) {
+			plan.SetConflicts(keyOf(importActions, selected))
+			list.Refresh()
+		})
+		allSelect.PlaceHolder = "Escolha para todos"
+		top.Add(container.NewBorder(nil, nil, widget.NewLabel("Todos os conflitos:"), nil, allSelect))
+	}
+
+	importBtn := widget.NewButton("📥 Importar", func() {
+		imported, err := m.repo.ApplyImport(plan)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+
+		win.Close()
+		m.refreshMainContent()
+		dialog.ShowInformation("Sucesso", fmt.Sprintf("%d registro(s) importado(s)", imported), m.win)
+	})
+
+	cancelBtn := widget.NewButton("✖ Cancelar", func() {
+		win.Close()
+	})
+
+	buttons := container.NewHBox(layout.NewSpacer(), cancelBtn, importBtn)
+
+	win.SetContent(container.NewBorder(top, buttons, nil, nil, list))
+	win.Resize(fyne.NewSize(recordsWidth, recordsHigh))
+	win.CenterOnScreen()
+	win.Show()
+	return nil
+}
+
+// importLine describes an incoming record and, when its day is already
+// recorded, the local records it is compared with
+func importLine(item *store.ImportItem) string {
+	line := item.Record.Local().Format(layoutBR) + " - " + recordText(item.Record)
+	if item.Status != store.ImportConflict {
+		return line
+	}
+
+	line += "  ⇄  local: "
+	for i, r := range item.Local {
+		if i > 0 {
+			line += ", "
+		}
+		line += recordText(r)
+	}
+	return line
+}
+
+// recordText names the response and area of a record followed by its observation
+func recordText(r store.PresenceRecord) string {
+	text := r.Response
+	if r.Area != "" {
+		text += " (" + r.Area + ")"
+	}
+	if r.Observation != "" {
+		text += ": " + r.Observation
+	}
+	return text
+}
@@ -0,0 +1,72 @@
+package program
+
+import (
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// yearsBack is how many years before the current one the year selector offers
+const yearsBack = 5
+
+// buildMonthNavigation returns the previous/next buttons and the month and
+// year selectors that choose the month shown in the main report
+func (m *MainApp) buildMonthNavigation() fyne.CanvasObject {
+	prevBtn := widget.NewButton("◀", func() {
+		m.showMonth(m.month.AddDate(0, -1, 0))
+	})
+
+	nextBtn := widget.NewButton("▶", func() {
+		m.showMonth(m.month.AddDate(0, 1, 0))
+	})
+
+	todayBtn := widget.NewButton("Hoje", func() {
+		m.showMonth(time.Now())
+	})
+	if m.isCurrentMonth() {
+		todayBtn.Disable()
+	}
+
+	monthSelect := widget.NewSelect(store.MonthNames, nil)
+	monthSelect.SetSelected(store.MonthNames[m.month.Month()-1])
+	monthSelect.OnChanged = func(selected string) {
+		for i, name := range store.MonthNames {
+			if name == selected {
+				m.showMonth(time.Date(m.month.Year(), time.Month(i+1), 1, 0, 0, 0, 0, m.month.Location()))
+				return
+			}
+		}
+	}
+
+	yearSelect := widget.NewSelect(m.selectableYears(), nil)
+	yearSelect.SetSelected(strconv.Itoa(m.month.Year()))
+	yearSelect.OnChanged = func(selected string) {
+		year, err := strconv.Atoi(selected)
+		if err != nil {
+			return
+		}
+		m.showMonth(time.Date(year, m.month.Month(), 1, 0, 0, 0, 0, m.month.Location()))
+	}
+
+	return container.NewBorder(nil, nil, prevBtn, container.NewHBox(todayBtn, nextBtn),
+		container.NewGridWithColumns(2, monthSelect, yearSelect))
+}
+
+// selectableYears lists the years offered by the year selector, always
+// including the year currently shown
+func (m *MainApp) selectableYears() []string {
+	now := time.Now().Year()
+	first := min(now-yearsBack, m.month.Year())
+	last := max(now+1, m.month.Year())
+
+	years := make([]string, 0, last-first+1)
+	for y := last; y >= first; y-- {
+		years = append(years, strconv.Itoa(y))
+	}
+	return years
+}
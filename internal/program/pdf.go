@@ -0,0 +1,64 @@
+package program
+
+import (
+	"os/user"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// showPDFReportForm asks for the name printed on the report of the month
+// shown in the main window and then for the file to write
+func (m *MainApp) showPDFReportForm() {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(profileName())
+
+	month := m.month
+	items := []*widget.FormItem{
+		widget.NewFormItem("Nome", nameEntry),
+		widget.NewFormItem("Mês", widget.NewLabel(store.MonthTitle(month))),
+	}
+
+	form := dialog.NewForm("Gerar relatório PDF", "💾 Gerar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			filePath := writer.URI().Path()
+			_ = writer.Close()
+
+			if !strings.HasSuffix(filePath, ".pdf") {
+				filePath += ".pdf"
+			}
+
+			if err := store.ExportMonthPDF(m.repo, filePath, strings.TrimSpace(nameEntry.Text), month); err != nil {
+				dialog.ShowError(err, m.win)
+				return
+			}
+
+			dialog.ShowInformation("Sucesso", "Relatório gerado com sucesso", m.win)
+		}, m.win)
+	}, m.win)
+	form.Resize(fyne.NewSize(width, 0))
+	form.Show()
+}
+
+// profileName returns the full name of the logged in user, or the login name
+func profileName() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	if name := strings.TrimSpace(strings.Split(u.Name, ",")[0]); name != "" {
+		return name
+	}
+	return u.Username
+}
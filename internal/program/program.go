@@ -1,14 +1,17 @@
+// Package program provides the core functionality for the attendance tracking application
 package program
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -18,14 +21,12 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 	"fyne.io/systray"
-	"github.com/google/uuid"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
+
+	"github.com/dyammarcano/presencial/internal/store"
 )
 
 const (
-	layoutISO = "2006-01-02"
-	layoutBR  = "02/01/2006"
+	layoutBR = "02/01/2006"
 
 	high       = 320
 	highPopup  = 80
@@ -33,27 +34,43 @@ const (
 	widthPopup = 200
 )
 
-type arr struct {
-	ValuesArea    []string `json:"areas"`
-	ValuesHeaders []string `json:"headers"`
-}
-
 // MainApp main app structure
 type MainApp struct {
-	*App
-	db       *gorm.DB
+	*store.App
+	repo     store.Repository
+	dbPath   string
 	app      fyne.App
 	win      fyne.Window
 	firstRun bool
-	records  []PresenceRecord
+	records  []store.PresenceRecord
+
+	responseTypes []store.ResponseType
+	holidays      []store.Holiday
+
+	// month is the first day of the month shown in the main report
+	month     time.Time
+	report    *store.ReportData
+	goal      store.GoalStatus
+	templates []store.ReportTemplate
+
+	// summaryFormat is the last format used to copy the month summary
+	summaryFormat store.SummaryFormat
+
+	// backupMu serializes automatic snapshots with the replacement of the database
+	backupMu     sync.Mutex
+	backupTicker *time.Ticker
 }
 
 // NewMainApp main app structure
 func NewMainApp(appName string) (*MainApp, error) {
+	month, _ := monthBounds(time.Now())
 	a := &MainApp{
 		app:     newSmallFontTheme(app.New()),
-		App:     &App{},
-		records: []PresenceRecord{},
+		App:     &store.App{},
+		records: []store.PresenceRecord{},
+		month:   month,
+
+		summaryFormat: store.SummaryText,
 	}
 
 	if err := a.setupDatabase(appName); err != nil {
@@ -64,6 +81,8 @@ func NewMainApp(appName string) (*MainApp, error) {
 		return nil, err
 	}
 
+	a.startBackups()
+
 	return a, nil
 }
 
@@ -94,41 +113,29 @@ func (m *MainApp) getAppDataFolder(appName string) string {
 }
 
 func (m *MainApp) setupDatabase(appName string) error {
-	dbPath := filepath.Join(m.getAppDataFolder(appName), "application.db")
+	m.dbPath = filepath.Join(m.getAppDataFolder(appName), "application.db")
 
-	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+	if _, err := os.Stat(m.dbPath); os.IsNotExist(err) {
 		m.firstRun = true
 	}
 
-	var err error
-	m.db, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	repo, err := store.NewSQLite(m.dbPath)
 	if err != nil {
-		return fmt.Errorf("erro ao conectar no banco de dados: %v", err)
-	}
-
-	if err = m.db.AutoMigrate(
-		&AppLanguage{},
-		&AppInteraction{},
-		&App{},
-		&PresenceRecord{},
-		&AppConfig{},
-	); err != nil {
-		return fmt.Errorf("erro ao migrar estruturas: %v", err)
+		return err
 	}
+	m.repo = repo
 
 	if m.firstRun {
-		if err := m.createDefaultApp(); err != nil {
-			return fmt.Errorf("erro ao criar dados padrão: %v", err)
+		if m.App, err = m.repo.CreateDefaultApp(); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
 func (m *MainApp) initApp() error {
-	if !m.firstRun {
-		if err := m.loadConfigFromDB(); err != nil {
-			return err
-		}
+	if err := m.loadConfigFromDB(); err != nil {
+		return err
 	}
 
 	m.buildMainMenu()
@@ -139,106 +146,224 @@ func (m *MainApp) initApp() error {
 		})
 	} else {
 		m.win.SetContent(m.buildMainContent())
+		m.showSplitDays()
 	}
 
 	return nil
 }
 
-func (m *MainApp) loadConfigFromDB() error {
-	if err := m.db.
-		Preload("Language").
-		Preload("Interaction").
-		Preload("AppConfig").
-		First(&m.App).Error; err != nil {
-		return fmt.Errorf("erro ao carregar dados do app: %w", err)
+// showSplitDays tells the user which days the migration of the database
+// turned into split days because they had more than one record
+func (m *MainApp) showSplitDays() {
+	days := m.repo.SplitDays()
+	if len(days) == 0 {
+		return
 	}
 
-	var allRecords []PresenceRecord
-	if err := m.db.Order("date DESC, time DESC").Find(&allRecords).Error; err != nil {
-		log.Printf("erro ao carregar registros anteriores: %v", err)
-	}
+	var lines []string
+	for _, d := range days {
+		day, err := time.ParseInLocation(store.LayoutISO, d, time.Local)
+		if err != nil {
+			continue
+		}
 
-	now := time.Now()
-	for _, r := range allRecords {
-		t, err := time.Parse(layoutISO, r.Date)
+		records, err := m.repo.RecordsBetween(day, day.AddDate(0, 0, 1))
 		if err != nil {
+			log.Printf("erro ao carregar registros do dia %s: %v", d, err)
 			continue
 		}
+		slices.Reverse(records)
 
-		if t.Month() == now.Month() && t.Year() == now.Year() {
-			m.records = append(m.records, r)
+		responses := make([]string, len(records))
+		for i, r := range records {
+			responses[i] = r.Response
 		}
+		lines = append(lines, fmt.Sprintf("%s: %s", day.Format(layoutBR), strings.Join(responses, ", ")))
+	}
+
+	text := widget.NewLabel(strings.Join(lines, "\n"))
+	text.Wrapping = fyne.TextWrapWord
+
+	info := dialog.NewCustom("Dias divididos", "OK", container.NewVBox(
+		widget.NewLabel("Os dias abaixo tinham mais de um registro e foram mantidos como dias divididos.\n"+
+			"Revise-os no calendário ou em \"Gerenciar Registros\" e exclua o que não for necessário:"),
+		container.NewVScroll(text),
+	), m.win)
+
+	info.Resize(fyne.NewSize(width, high))
+	info.Show()
+}
+
+func (m *MainApp) loadConfigFromDB() error {
+	a, err := m.repo.LoadApp()
+	if err != nil {
+		return err
+	}
+	m.App = a
+
+	if m.responseTypes, err = m.repo.ResponseTypes(); err != nil {
+		return err
+	}
+
+	from, to := monthBounds(m.month)
+	if m.records, err = m.repo.RecordsBetween(from, to); err != nil {
+		log.Printf("erro ao carregar registros do mês: %v", err)
+	}
+
+	if m.holidays, err = m.repo.Holidays(from, to); err != nil {
+		log.Printf("erro ao carregar feriados: %v", err)
+	}
+
+	// Past months are judged by the goal in force at their end
+	if m.report, err = store.BuildReport(m.repo, m.month, time.Now()); err != nil {
+		return err
+	}
+	m.goal = m.report.Status
+
+	if m.templates, err = m.repo.ReportTemplates(); err != nil {
+		log.Printf("erro ao carregar modelos de relatório: %v", err)
 	}
 
 	return nil
 }
 
+// isCurrentMonth reports whether the main report shows the current month
+func (m *MainApp) isCurrentMonth() bool {
+	now := time.Now()
+	return m.month.Year() == now.Year() && m.month.Month() == now.Month()
+}
+
+// showMonth moves the main report to the month of t
+func (m *MainApp) showMonth(t time.Time) {
+	m.month, _ = monthBounds(t)
+	m.refreshMainContent()
+}
+
+// monthBounds returns the first day of t's month and the first day of the next one
+func monthBounds(t time.Time) (time.Time, time.Time) {
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return from, from.AddDate(0, 1, 0)
+}
+
+// refreshMainContent reloads the records from the database and redraws the main report
+func (m *MainApp) refreshMainContent() {
+	if err := m.loadConfigFromDB(); err != nil {
+		dialog.ShowError(err, m.win)
+		return
+	}
+	m.win.SetContent(m.buildMainContent())
+}
+
 func (m *MainApp) buildMainContent() fyne.CanvasObject {
-	reportLabel := widget.NewLabel(m.loadMonthlyReport())
-	reportLabel.Wrapping = fyne.TextWrapWord
+	var report fyne.CanvasObject
+	if m.AppConfig.CalendarView {
+		summary := widget.NewLabel(m.reportHeader() + "\n" + strings.TrimSpace(m.forecastReport()))
+		summary.Wrapping = fyne.TextWrapWord
+		report = container.NewVBox(summary, m.buildCalendar())
+	} else {
+		reportLabel := widget.NewLabel(m.loadMonthlyReport())
+		reportLabel.Wrapping = fyne.TextWrapWord
+		report = reportLabel
+	}
 
 	observation := ""
 
 	label := widget.NewLabel("Como você está trabalhando hoje?")
 
-	buttonPresencial := widget.NewButton("✔ Presencial", func() {
-		if len(m.records) >= m.AppConfig.DefaultGoal {
-			info := dialog.NewInformation("Meta atingida",
-				fmt.Sprintf("Você já atingiu a meta de %d dias presenciais neste mês!", m.AppConfig.DefaultGoal), m.win,
-			)
-
-			info.SetOnClosed(func() {
-				m.showAreaPopup(observation)
-			})
-
-			info.Show()
+	dayEntry := newDayEntry(time.Now())
+	dayEntry.OnChanged = func(d *time.Time) {
+		if d == nil || store.SameDay(*d, time.Now()) {
+			label.SetText("Como você está trabalhando hoje?")
 			return
 		}
-		m.showAreaPopup(observation)
-	})
+		label.SetText(fmt.Sprintf("Como você trabalhou em %s?", d.Format(layoutBR)))
+	}
 
-	buttonRemoto := widget.NewButton("✔ Remoto", func() {
-		if err := m.savePresenceToDB(&PresenceRecord{Response: "Remoto", Observation: observation, Area: "Remoto"}); err != nil {
-			m.app.SendNotification(&fyne.Notification{
-				Title:   "Erro",
-				Content: err.Error(),
-			})
-			<-time.After(10 * time.Millisecond)
-			return
-		}
+	buttons := container.New(layout.NewGridLayoutWithColumns(2))
+	for _, rt := range m.responseTypes {
+		buttons.Add(widget.NewButton(fmt.Sprintf("%s %s", rt.Icon, rt.Name), func() {
+			day := selectedDay(dayEntry)
+			if !m.checkDay(day) {
+				return
+			}
 
-		m.app.SendNotification(&fyne.Notification{
-			Title:   "Salvo",
-			Content: "Trabalho remoto registrado com sucesso.",
-		})
-		<-time.After(10 * time.Millisecond)
-		m.app.Quit()
-	})
+			next := func() { m.registerResponse(rt, observation, day) }
 
-	buttons := container.New(
-		layout.NewGridLayoutWithColumns(2),
-		buttonRemoto,
-		buttonPresencial,
-	)
+			if rt.CountsTowardGoal {
+				if cfg, status, ok := m.goalOn(day); ok && status.Reached() {
+					info := dialog.NewInformation("Meta atingida",
+						fmt.Sprintf("Você já atingiu a meta de %d dias presenciais %s (%s)!",
+							status.Target, cfg.Period().Text(status.From), cfg.Describe()), m.win,
+					)
+					info.SetOnClosed(next)
+					info.Show()
+					return
+				}
+			}
+			next()
+		}))
+	}
+
+	copyButton := widget.NewButton("📋 Copiar resumo", func() {
+		m.showCopySummaryForm()
+	})
 
 	form := container.NewVBox(
-		reportLabel,
+		m.buildMonthNavigation(),
+		report,
+		container.NewHBox(layout.NewSpacer(), copyButton),
 		label,
+		container.NewBorder(nil, nil, widget.NewLabel("Data:"), nil, dayEntry),
 		buttons,
 	)
 
 	return form
 }
 
-func (m *MainApp) showAreaPopup(observation string) {
+// responseNames returns the names of the response catalog in display order
+func (m *MainApp) responseNames() []string {
+	names := make([]string, 0, len(m.responseTypes))
+	for _, rt := range m.responseTypes {
+		names = append(names, rt.Name)
+	}
+	return names
+}
+
+// goalOn evaluates the goal in force on day for the period containing it
+func (m *MainApp) goalOn(day time.Time) (*store.AppConfig, store.GoalStatus, bool) {
+	cfg, err := m.repo.GoalAt(day)
+	if err != nil {
+		return nil, store.GoalStatus{}, false
+	}
+
+	status, err := store.EvaluateGoal(m.repo, cfg, day)
+	if err != nil {
+		return nil, store.GoalStatus{}, false
+	}
+	return cfg, status, true
+}
+
+// registerResponse saves rt for day, asking for the work area first when rt requires one
+func (m *MainApp) registerResponse(rt store.ResponseType, observation string, day time.Time) {
+	if rt.RequiresArea {
+		m.showAreaPopup(rt, observation, day)
+		return
+	}
+
+	m.savePresence(&store.PresenceRecord{Response: rt.Name, Observation: observation}, day,
+		fmt.Sprintf("%s registrado com sucesso.", rt.Name), nil)
+}
+
+func (m *MainApp) showAreaPopup(rt store.ResponseType, observation string, day time.Time) {
 	newArea := ""
 
-	var area arr
-	_ = json.Unmarshal([]byte(m.Interaction.AreaOptions), &area)
+	areas, _ := m.repo.Areas()
 
-	selectWidget := widget.NewSelect(area.ValuesArea, func(selected string) { newArea = selected })
+	selectWidget := widget.NewSelect(areas, func(selected string) { newArea = selected })
 	selectWidget.PlaceHolder = "Selecione o local de trabalho"
 
+	dayEntry := newDayEntry(day)
+
 	var pop dialog.Dialog
 
 	acceptButton := widget.NewButton("✔ Aceitar", func() {
@@ -247,23 +372,15 @@ func (m *MainApp) showAreaPopup(observation string) {
 			return
 		}
 
-		if err := m.savePresenceToDB(&PresenceRecord{Response: "Presencial", Observation: observation, Area: newArea}); err != nil {
-			m.app.SendNotification(&fyne.Notification{
-				Title:   "Erro",
-				Content: err.Error(),
-			})
-			<-time.After(10 * time.Millisecond)
+		day := selectedDay(dayEntry)
+		if !m.checkDay(day) {
 			return
 		}
 
 		pop.Hide()
 
-		m.app.SendNotification(&fyne.Notification{
-			Title:   "Salvo",
-			Content: "Presença presencial registrada com sucesso.",
-		})
-		<-time.After(10 * time.Millisecond)
-		m.app.Quit()
+		m.savePresence(&store.PresenceRecord{Response: rt.Name, Observation: observation, Area: newArea}, day,
+			fmt.Sprintf("%s registrado com sucesso em %s.", rt.Name, newArea), nil)
 	})
 
 	cancelButton := widget.NewButton("✖ Cancelar", func() {
@@ -271,8 +388,9 @@ func (m *MainApp) showAreaPopup(observation string) {
 	})
 
 	pop = dialog.NewCustomWithoutButtons("Local de Trabalho", container.NewVBox(
-		widget.NewLabel("Selecione onde você está trabalhando presencialmente:"),
+		widget.NewLabel(fmt.Sprintf("Selecione onde você está trabalhando (%s):", rt.Name)),
 		selectWidget,
+		container.NewBorder(nil, nil, widget.NewLabel("Data:"), nil, dayEntry),
 		container.New(
 			layout.NewGridLayoutWithColumns(2),
 			cancelButton,
@@ -284,37 +402,146 @@ func (m *MainApp) showAreaPopup(observation string) {
 	pop.Show()
 }
 
-func (m *MainApp) savePresenceToDB(presence *PresenceRecord) error {
-	presence.Date = time.Now().Format(layoutISO)
-	presence.Time = time.Now().Format("15:04:05")
-	return m.db.Create(presence).Error
+// checkDay validates that a record may be saved for day, warning the user when it may not
+func (m *MainApp) checkDay(day time.Time) bool {
+	if err := store.CheckDay(day); err != nil {
+		dialog.ShowError(err, m.win)
+		return false
+	}
+	return true
 }
 
-func (m *MainApp) updateGoal(text string) error {
-	dg, err := strconv.Atoi(text)
-	if err != nil || dg < 1 || dg > 24 {
-		dialog.ShowError(fmt.Errorf("valores inválidos"), m.win)
-		return err
+// savePresence stores presence for day, asking the user how to resolve the
+// conflict when the day already has a record. done runs once the record is
+// saved; when nil the daily prompt behavior of finishSave applies
+func (m *MainApp) savePresence(presence *store.PresenceRecord, day time.Time, msg string, done func()) {
+	err := m.savePresenceToDB(presence, day, store.ConflictReject)
+	if errors.Is(err, store.ErrDayRecorded) {
+		m.showConflictPopup(day, err, func(policy store.ConflictPolicy) {
+			if err := m.savePresenceToDB(presence, day, policy); err != nil {
+				m.notifyError(err)
+				return
+			}
+			m.finishSave(msg, day, done)
+		})
+		return
 	}
 
-	if err := m.db.Preload("AppConfig").Where("app_id = ?", m.App).Error; err != nil {
-		dialog.ShowError(fmt.Errorf("erro ao buscar a: %w", err), m.win)
-		return err
+	if err != nil {
+		m.notifyError(err)
+		return
 	}
+	m.finishSave(msg, day, done)
+}
 
-	m.AppConfig.DefaultGoal = dg
+func (m *MainApp) savePresenceToDB(presence *store.PresenceRecord, day time.Time, policy store.ConflictPolicy) error {
+	presence.TakenAt = store.AtDay(day, time.Now())
+	return m.repo.SaveRecord(presence, policy)
+}
+
+// showConflictPopup asks whether to replace the existing record of day, keep
+// both as a split day or cancel, calling resolve unless the user cancels
+func (m *MainApp) showConflictPopup(day time.Time, conflict error, resolve func(store.ConflictPolicy)) {
+	var pop dialog.Dialog
+
+	replaceButton := widget.NewButton("♻ Substituir", func() {
+		pop.Hide()
+		resolve(store.ConflictReplace)
+	})
+
+	splitButton := widget.NewButton("➗ Manter ambos", func() {
+		pop.Hide()
+		resolve(store.ConflictSplit)
+	})
+
+	cancelButton := widget.NewButton("✖ Cancelar", func() {
+		pop.Hide()
+	})
+
+	msg := widget.NewLabel(fmt.Sprintf("%s: %s.\nSubstituir o registro existente ou manter ambos como dia dividido?",
+		day.Format(layoutBR), conflict.Error()))
+	msg.Wrapping = fyne.TextWrapWord
+
+	pop = dialog.NewCustomWithoutButtons("Dia já registrado", container.NewVBox(
+		msg,
+		container.New(
+			layout.NewGridLayoutWithColumns(3),
+			cancelButton,
+			splitButton,
+			replaceButton,
+		),
+	), m.win)
+
+	pop.Resize(fyne.NewSize(width, highPopup))
+	pop.Show()
+}
+
+// notifyError reports an error through a system notification
+func (m *MainApp) notifyError(err error) {
+	m.app.SendNotification(&fyne.Notification{
+		Title:   "Erro",
+		Content: err.Error(),
+	})
+	<-time.After(10 * time.Millisecond)
+}
+
+// finishSave notifies the user of a saved record and runs done. Without
+// done, registering today closes the app as before and registering another
+// day keeps it open for further entries
+func (m *MainApp) finishSave(msg string, day time.Time, done func()) {
+	m.app.SendNotification(&fyne.Notification{
+		Title:   "Salvo",
+		Content: msg,
+	})
+	<-time.After(10 * time.Millisecond)
+
+	if done != nil {
+		done()
+		return
+	}
+
+	if store.SameDay(day, time.Now()) {
+		m.app.Quit()
+		return
+	}
+	m.refreshMainContent()
+}
+
+// newDayEntry returns a date picker preset to day
+func newDayEntry(day time.Time) *widget.DateEntry {
+	entry := widget.NewDateEntry()
+	entry.SetDate(&day)
+	return entry
+}
+
+// selectedDay returns the day picked in entry, or today when it is empty
+func selectedDay(entry *widget.DateEntry) time.Time {
+	if entry.Date == nil {
+		return store.StartOfDay(time.Now())
+	}
+	return store.StartOfDay(*entry.Date)
+}
 
-	if err := m.db.Save(&m.AppConfig).Error; err != nil {
-		dialog.ShowError(fmt.Errorf("erro ao salvar config: %w", err), m.win)
+func (m *MainApp) updateGoal(text string, mode store.GoalMode, period store.GoalPeriod) error {
+	dg, err := strconv.Atoi(text)
+	if err != nil {
+		return store.ErrInvalidGoal
+	}
+
+	if err := m.repo.SaveGoal(dg, mode, period); err != nil {
 		return err
 	}
+
+	m.AppConfig.DefaultGoal = dg
+	m.AppConfig.GoalMode = mode
+	m.AppConfig.GoalPeriod = period
 	return nil
 }
 
 func (m *MainApp) showHeaderConfigForm(onComplete func()) {
-	var current arr
-	if err := json.Unmarshal([]byte(m.Interaction.Headers), &current); err != nil {
-		dialog.ShowError(fmt.Errorf("erro ao carregar headers: %w", err), m.win)
+	headers, err := m.repo.Headers()
+	if err != nil {
+		dialog.ShowError(err, m.win)
 		return
 	}
 
@@ -327,7 +554,7 @@ func (m *MainApp) showHeaderConfigForm(onComplete func()) {
 
 	formContent := container.NewVBox()
 
-	for _, header := range current.ValuesHeaders {
+	for _, header := range headers {
 		entry := widget.NewEntry()
 		entry.SetText(header)
 
@@ -394,15 +621,13 @@ func (m *MainApp) showHeaderConfigForm(onComplete func()) {
 			}
 		}
 
-		newData, err := json.Marshal(map[string][]string{"headers": newHeaders})
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("erro ao serializar headers: %w", err), m.win)
+		if err := m.repo.SaveHeaders(newHeaders); err != nil {
+			dialog.ShowError(err, m.win)
 			return
 		}
 
-		m.Interaction.Headers = string(newData)
-		if err := m.db.Save(&m.Interaction).Error; err != nil {
-			dialog.ShowError(fmt.Errorf("erro ao salvar no banco: %w", err), m.win)
+		if err := m.loadConfigFromDB(); err != nil {
+			dialog.ShowError(err, m.win)
 			return
 		}
 
@@ -429,9 +654,9 @@ func (m *MainApp) showHeaderConfigForm(onComplete func()) {
 }
 
 func (m *MainApp) showAreaConfigForm(onComplete func()) {
-	var area arr
-	if err := json.Unmarshal([]byte(m.Interaction.AreaOptions), &area); err != nil {
-		dialog.ShowError(fmt.Errorf("erro ao carregar áreas: %w", err), m.win)
+	areas, err := m.repo.Areas()
+	if err != nil {
+		dialog.ShowError(err, m.win)
 		return
 	}
 
@@ -443,7 +668,7 @@ func (m *MainApp) showAreaConfigForm(onComplete func()) {
 		formContainer.Objects = nil
 		entries = []*widget.Entry{}
 
-		for _, val := range area.ValuesArea {
+		for _, val := range areas {
 			entry := widget.NewEntry()
 			entry.SetText(val)
 			entry.MultiLine = false
@@ -455,7 +680,7 @@ func (m *MainApp) showAreaConfigForm(onComplete func()) {
 				return func() {
 					for i, en := range entries {
 						if en == e {
-							area.ValuesArea = append(area.ValuesArea[:i], area.ValuesArea[i+1:]...)
+							areas = append(areas[:i], areas[i+1:]...)
 							refreshForm()
 							return
 						}
@@ -468,7 +693,7 @@ func (m *MainApp) showAreaConfigForm(onComplete func()) {
 		}
 
 		addBtn := widget.NewButton("➕ Adicionar nova área", func() {
-			area.ValuesArea = append(area.ValuesArea, "")
+			areas = append(areas, "")
 			refreshForm()
 		})
 		formContainer.Add(addBtn)
@@ -482,17 +707,15 @@ func (m *MainApp) showAreaConfigForm(onComplete func()) {
 				newAreas = append(newAreas, val)
 			}
 		}
-		area.ValuesArea = newAreas
+		areas = newAreas
 
-		areaJSON, err := json.Marshal(area)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("erro ao serializar áreas: %w", err), m.win)
+		if err := m.repo.SaveAreas(areas); err != nil {
+			dialog.ShowError(err, m.win)
 			return
 		}
 
-		m.Interaction.AreaOptions = string(areaJSON)
-		if err := m.db.Save(&m.Interaction).Error; err != nil {
-			dialog.ShowError(fmt.Errorf("erro ao salvar no banco de dados: %w", err), m.win)
+		if err := m.loadConfigFromDB(); err != nil {
+			dialog.ShowError(err, m.win)
 			return
 		}
 
@@ -550,6 +773,15 @@ func (m *MainApp) buildMainMenu() {
 				dialog.ShowInformation("Sucesso", "Dados exportados com sucesso", m.win)
 			}, m.win)
 		}),
+		fyne.NewMenuItem("Exportar Dados (CSV)", func() {
+			m.showCSVExportForm()
+		}),
+		fyne.NewMenuItem("Exportar Dados (XLSX)", func() {
+			m.showXLSXExportForm()
+		}),
+		fyne.NewMenuItem("Exportar Dados (ICS)", func() {
+			m.showICSExportForm()
+		}),
 		fyne.NewMenuItem("Importar Dados (JSON)", func() {
 			dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
 				if err != nil || reader == nil {
@@ -567,19 +799,66 @@ func (m *MainApp) buildMainMenu() {
 
 				if err := m.importFromJSON(filePath); err != nil {
 					dialog.ShowError(err, m.win)
+				}
+			}, m.win)
+		}),
+		fyne.NewMenuItem("Importar Dados (CSV)", func() {
+			dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+				if err != nil || reader == nil {
 					return
 				}
+				filePath := reader.URI().Path()
+				_ = reader.Close()
 
-				dialog.ShowInformation("Sucesso", "Dados importados com sucesso", m.win)
-				m.win.SetContent(m.buildMainContent())
+				if err := m.showCSVImportWizard(filePath); err != nil {
+					dialog.ShowError(err, m.win)
+				}
 			}, m.win)
 		}),
+		fyne.NewMenuItem("Importar Dados (ICS)", func() {
+			dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+				if err != nil || reader == nil {
+					return
+				}
+				filePath := reader.URI().Path()
+				_ = reader.Close()
+
+				if err := m.showICSImportWizard(filePath); err != nil {
+					dialog.ShowError(err, m.win)
+				}
+			}, m.win)
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Backup Completo", func() {
+			m.showBackupExport()
+		}),
+		fyne.NewMenuItem("Restaurar Backup Completo", func() {
+			m.showBackupRestore()
+		}),
+		fyne.NewMenuItem("Backups Automáticos…", func() {
+			m.showSnapshotWindow()
+		}),
 		fyne.NewMenuItemSeparator(),
 		fyne.NewMenuItem("Sair", func() {
 			m.app.Quit()
 		}),
 	)
 
+	calendarItem := fyne.NewMenuItem(calendarMenuLabel, nil)
+	calendarItem.Checked = m.AppConfig.CalendarView
+	calendarItem.Action = func() {
+		enabled := !m.AppConfig.CalendarView
+		if err := m.repo.SaveCalendarView(enabled); err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		m.AppConfig.CalendarView = enabled
+		calendarItem.Checked = enabled
+		m.win.MainMenu().Refresh()
+		m.refreshMainContent()
+	}
+
 	editMenu := fyne.NewMenu("Editar",
 		fyne.NewMenuItem("Configurar Meta de Dias", func() {
 			m.showConfigForm(func() {
@@ -597,6 +876,43 @@ func (m *MainApp) buildMainMenu() {
 				m.win.SetContent(m.buildMainContent())
 			})
 		}),
+		fyne.NewMenuItem("Editar Tipos de Resposta", func() {
+			m.showResponseTypeConfigForm(func() {
+				m.win.SetContent(m.buildMainContent())
+			})
+		}),
+		fyne.NewMenuItem("Feriados", func() {
+			m.showHolidayConfigForm(func() {
+				m.win.SetContent(m.buildMainContent())
+			})
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Gerenciar Registros", func() {
+			m.showRecordsWindow()
+		}),
+		calendarItem,
+	)
+
+	reportMenu := fyne.NewMenu("Relatórios",
+		fyne.NewMenuItem("Resumo por Período", func() {
+			m.showSummaryWindow()
+		}),
+		fyne.NewMenuItem("Gerar relatório PDF", func() {
+			m.showPDFReportForm()
+		}),
+		fyne.NewMenuItem("Estatísticas", func() {
+			m.showStatsWindow()
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Modelos de Relatório", func() {
+			m.showTemplateWindow()
+		}),
+		fyne.NewMenuItem("Exportar Relatório por Modelo", func() {
+			m.showTemplateExportForm()
+		}),
+		fyne.NewMenuItem("Copiar Resumo", func() {
+			m.showCopySummaryForm()
+		}),
 	)
 
 	helpMenu := fyne.NewMenu("Ajuda",
@@ -611,7 +927,7 @@ func (m *MainApp) buildMainMenu() {
 		}),
 	)
 
-	m.win.SetMainMenu(fyne.NewMainMenu(fileMenu, editMenu, helpMenu, aboutMenu))
+	m.win.SetMainMenu(fyne.NewMainMenu(fileMenu, editMenu, reportMenu, helpMenu, aboutMenu))
 }
 
 func (m *MainApp) showConfigForm(onComplete func()) {
@@ -622,17 +938,39 @@ func (m *MainApp) showConfigForm(onComplete func()) {
 		entryDefault.SetText(strconv.Itoa(m.AppConfig.DefaultGoal))
 	}
 
+	mode := m.AppConfig.Mode()
+	modeSelect := widget.NewSelect(labels(goalModeLabels), func(selected string) {
+		mode = keyOf(goalModeLabels, selected)
+		if mode == store.GoalPercent {
+			entryDefault.SetPlaceHolder("Percentual dos dias úteis (ex: 40)")
+			return
+		}
+		entryDefault.SetPlaceHolder(fmt.Sprintf("Dias presenciais (ex: %d)", m.AppConfig.DefaultGoal))
+	})
+	modeSelect.SetSelected(labelOf(goalModeLabels, mode))
+
+	period := m.AppConfig.Period()
+	periodSelect := widget.NewSelect(labels(goalPeriodLabels), func(selected string) {
+		period = keyOf(goalPeriodLabels, selected)
+	})
+	periodSelect.SetSelected(labelOf(goalPeriodLabels, period))
+
 	saveBtn := widget.NewButton("💾 Salvar", func() {
 		if entryDefault.Text == "" {
 			dialog.ShowError(fmt.Errorf("o valor precisa não pode estar vacio"), m.win)
 			return
 		}
 
-		if err := m.updateGoal(entryDefault.Text); err != nil {
+		if err := m.updateGoal(entryDefault.Text, mode, period); err != nil {
 			dialog.ShowError(fmt.Errorf("erro ao atualizar meta: %w", err), m.win)
 			return
 		}
 
+		if err := m.loadConfigFromDB(); err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
 		dialog.ShowInformation("Salvo", "Configuração salva com sucesso", m.win)
 		onComplete()
 	})
@@ -646,6 +984,10 @@ func (m *MainApp) showConfigForm(onComplete func()) {
 	form := container.NewVBox(
 		widget.NewLabel("Configure os dias de presença:"),
 		entryDefault,
+		container.New(layout.NewFormLayout(),
+			widget.NewLabel("Modo:"), modeSelect,
+			widget.NewLabel("Período:"), periodSelect,
+		),
 		buttons,
 	)
 
@@ -654,167 +996,83 @@ func (m *MainApp) showConfigForm(onComplete func()) {
 	m.win.Show()
 }
 
-func (m *MainApp) createDefaultApp() error {
-	var count int64
-	m.db.Model(&App{}).Count(&count)
-	if count > 0 {
-		return nil
-	}
-
-	m.Language = AppLanguage{
-		WindowName:  "Controle de Presença",
-		Title:       "Controle de Presença",
-		Welcome:     "Bem-vindo",
-		Goal:        "Meta de dias presenciais",
-		Report:      "Relatório de Presença",
-		Observation: "Observação",
-		Area:        "Área",
-		Save:        "Salvar",
-		Cancel:      "Cancelar",
-		Yes:         "Sim",
-		No:          "Não",
-		Close:       "Fechar",
-		Error:       "Erro",
-		Success:     "Sucesso",
-		SuccessMsg:  "Presença registrada com sucesso",
-		ErrorMsg:    "Erro ao registrar presença",
-		Warning:     "Aviso",
-		WarningMsg:  "Meta já atingida",
-		Info:        "Informação",
-	}
-
-	if err := m.db.Create(&m.Language).Error; err != nil {
-		return err
-	}
-
-	m.Interaction = AppInteraction{
-		ExtraLabel:  "adicional",
-		AreaOptions: `{"areas": ["CT", "CEIC", "AG", "OUTRO"]}`,
-		Headers:     `{"headers": ["data", "hora", "resposta", "observacao", "area"]}`,
-	}
-
-	if err := m.db.Create(&m.Interaction).Error; err != nil {
-		return err
-	}
-
-	m.AppConfig = AppConfig{
-		DefaultGoal: 4,
-	}
-
-	if err := m.db.Create(&m.AppConfig).Error; err != nil {
-		return err
-	}
-
-	m.App = &App{
-		AppID:         uuid.New(),
-		Name:          "PresencialApp",
-		Theme:         "light",
-		LanguageID:    m.Language.ID,
-		InteractionID: m.Interaction.ID,
-		AppConfigID:   m.AppConfig.ID,
+func (m *MainApp) loadMonthlyReport() string {
+	if tpl, ok := m.template(m.AppConfig.MainTemplateID); ok {
+		text, err := store.RenderTemplate(tpl.Body, m.report)
+		if err != nil {
+			return fmt.Sprintf("Erro no modelo %q: %v", tpl.Name, err)
+		}
+		return text
 	}
 
-	return m.db.Create(&m.App).Error
-}
-
-func (m *MainApp) loadMonthlyReport() string {
 	var report string
-	var presencialCount int
 
 	for _, r := range m.records {
-		t, err := time.Parse(layoutISO, r.Date)
-		if err != nil {
-			continue
-		}
+		t := r.Local()
 
-		switch r.Response {
-		case "Presencial":
-			report += fmt.Sprintf("🏢 %s - %s (Presencial)\n", t.Format(layoutBR), r.Area)
-			presencialCount++
-		case "Remoto":
-			report += fmt.Sprintf("🏠 %s - Trabalho Remoto\n", t.Format(layoutBR))
-		default:
+		rt, ok := m.responseType(r.Response)
+		switch {
+		case !ok:
 			report += fmt.Sprintf("☑️ %s - %s\n", t.Format(layoutBR), r.Area)
+		case r.Area != "":
+			report += fmt.Sprintf("%s %s - %s (%s)\n", rt.Icon, t.Format(layoutBR), r.Area, rt.Name)
+		default:
+			report += fmt.Sprintf("%s %s - %s\n", rt.Icon, t.Format(layoutBR), rt.Name)
 		}
 	}
 
 	// Only show pending for presencial goal
-	for i := presencialCount; i < m.AppConfig.DefaultGoal; i++ {
+	for range m.goal.Remaining() {
 		report += "🔲 (presencial pendente)\n"
 	}
 
-	return fmt.Sprintf("Você registrou %d dia(s) presencial(is) neste mês:\n\n%s", presencialCount, report)
-}
+	report += m.forecastReport()
 
-// exportToJSON exports all presence records to a JSON file
-func (m *MainApp) exportToJSON(filePath string) error {
-	var allRecords []PresenceRecord
-	if err := m.db.Order("date DESC, time DESC").Find(&allRecords).Error; err != nil {
-		return fmt.Errorf("erro ao carregar registros: %w", err)
-	}
-
-	data, err := json.MarshalIndent(allRecords, "", "  ")
-	if err != nil {
-		return fmt.Errorf("erro ao serializar dados: %w", err)
-	}
-
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("erro ao salvar arquivo: %w", err)
+	if len(m.holidays) > 0 {
+		report += "\nFeriados do mês:\n"
+		for _, h := range m.holidays {
+			report += fmt.Sprintf("🎉 %s - %s\n", h.Date().Format(layoutBR), h.Name)
+		}
 	}
 
-	return nil
+	return fmt.Sprintf("%s:\n\n%s", m.reportHeader(), report)
 }
 
-// importFromJSON imports presence records from a JSON file
-func (m *MainApp) importFromJSON(filePath string) error {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("erro ao ler arquivo: %w", err)
-	}
-
-	var records []PresenceRecord
-	if err := json.Unmarshal(data, &records); err != nil {
-		return fmt.Errorf("erro ao processar JSON: %w", err)
+// reportHeader summarizes the goal days recorded against the goal of the
+// period shown. Goals of other periods show the days of the month itself first
+func (m *MainApp) reportHeader() string {
+	g := m.report.Goal
+	if !g.Monthly {
+		return fmt.Sprintf("Você registrou %d dia(s) presencial(is) em %s e %d %s%s",
+			g.MonthDone, m.report.MonthTitle, g.Done, g.Period, g.Note)
 	}
+	return fmt.Sprintf("Você registrou %d dia(s) presencial(is) %s%s", g.Done, g.Period, g.Note)
+}
 
-	// Validate records
-	for i, record := range records {
-		if record.Date == "" || record.Time == "" || record.Response == "" {
-			return fmt.Errorf("registro inválido na posição %d: campos obrigatórios ausentes", i)
-		}
-
-		// Validate date format
-		if _, err := time.Parse(layoutISO, record.Date); err != nil {
-			return fmt.Errorf("formato de data inválido no registro %d: %s", i, record.Date)
+// responseType looks up a response type of the catalog by name
+func (m *MainApp) responseType(name string) (store.ResponseType, bool) {
+	for _, rt := range m.responseTypes {
+		if rt.Name == name {
+			return rt, true
 		}
 	}
+	return store.ResponseType{}, false
+}
 
-	// Begin transaction
-	tx := m.db.Begin()
-
-	// Import records
-	for _, record := range records {
-		// Check if record already exists
-		var count int64
-		tx.Model(&PresenceRecord{}).
-			Where("date = ? AND time = ?", record.Date, record.Time).
-			Count(&count)
-
-		if count == 0 {
-			if err := tx.Create(&record).Error; err != nil {
-				tx.Rollback()
-				return fmt.Errorf("erro ao importar registro: %w", err)
-			}
-		}
-	}
+// exportToJSON exports all presence records to a JSON file
+func (m *MainApp) exportToJSON(filePath string) error {
+	return store.ExportJSON(m.repo, filePath)
+}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("erro ao finalizar importação: %w", err)
+// importFromJSON reads presence records from a JSON file and shows the
+// import preview, nothing is written until it is confirmed there
+func (m *MainApp) importFromJSON(filePath string) error {
+	records, err := store.ReadJSON(filePath)
+	if err != nil {
+		return err
 	}
 
-	// Reload current month records
-	return m.loadConfigFromDB()
+	return m.showImportPreview(records)
 }
 
 // setupTrayIcon configures the system tray icon and menu
@@ -838,7 +1096,9 @@ func (m *MainApp) setupTrayIcon() {
 	mShow := systray.AddMenuItem("Mostrar Janela", "Mostrar a janela principal")
 	systray.AddSeparator()
 	mExport := systray.AddMenuItem("Exportar Dados (JSON)", "Exportar registros para JSON")
+	mExportCSV := systray.AddMenuItem("Exportar Dados (CSV)", "Exportar registros para CSV")
 	mImport := systray.AddMenuItem("Importar Dados (JSON)", "Importar registros de JSON")
+	mCopy := systray.AddMenuItem("Copiar Resumo", "Copiar o resumo do mês atual")
 	systray.AddSeparator()
 	mQuit := systray.AddMenuItem("Sair", "Fechar o aplicativo")
 
@@ -873,6 +1133,28 @@ func (m *MainApp) setupTrayIcon() {
 						Content: "Dados exportados para: " + tempFile,
 					})
 				}()
+			case <-mExportCSV.ClickedCh:
+				// The export options need the window, so show it along with the form
+				fyne.Do(func() {
+					m.win.Show()
+					m.showCSVExportForm()
+				})
+			case <-mCopy.ClickedCh:
+				// The clipboard belongs to the main thread
+				fyne.Do(func() {
+					if err := m.copyCurrentSummary(); err != nil {
+						m.app.SendNotification(&fyne.Notification{
+							Title:   "Erro",
+							Content: "Falha ao copiar resumo: " + err.Error(),
+						})
+						return
+					}
+
+					m.app.SendNotification(&fyne.Notification{
+						Title:   "Resumo copiado",
+						Content: "Resumo do mês copiado para a área de transferência",
+					})
+				})
 			case <-mImport.ClickedCh:
 				// Show the window to allow user to use the import menu option
 				go func() {
@@ -0,0 +1,305 @@
+package program
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+const (
+	layoutMonth = "01/2006"
+	allMonths   = "Todos os meses"
+
+	recordsWidth = 640
+	recordsHigh  = 420
+)
+
+var recordColumns = []string{"Data", "Hora", "Resposta", "Área", "Observação"}
+
+// recordsBrowser holds the state of the records window
+type recordsBrowser struct {
+	m           *MainApp
+	win         fyne.Window
+	table       *widget.Table
+	monthSelect *widget.Select
+	all         []store.PresenceRecord
+	visible     []store.PresenceRecord
+	month       string
+	sortCol     int
+	sortAsc     bool
+	selected    int
+}
+
+// showRecordsWindow opens a window listing the presence records with edit and delete actions
+func (m *MainApp) showRecordsWindow() {
+	b := &recordsBrowser{
+		m:        m,
+		win:      m.app.NewWindow("Registros"),
+		month:    allMonths,
+		selected: -1,
+	}
+
+	if err := b.reload(); err != nil {
+		dialog.ShowError(err, m.win)
+		return
+	}
+
+	b.table = widget.NewTableWithHeaders(
+		func() (int, int) { return len(b.visible), len(recordColumns) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(recordCell(&b.visible[id.Row], id.Col))
+		},
+	)
+	b.table.ShowHeaderColumn = false
+	b.table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewButton("", nil)
+	}
+	b.table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		btn := o.(*widget.Button)
+		btn.SetText(b.headerText(id.Col))
+		btn.OnTapped = func() { b.sortBy(id.Col) }
+	}
+	b.table.OnSelected = func(id widget.TableCellID) { b.selected = id.Row }
+	b.table.OnUnselected = func(widget.TableCellID) { b.selected = -1 }
+
+	for col, w := range []float32{90, 70, 90, 80, 260} {
+		b.table.SetColumnWidth(col, w)
+	}
+
+	b.monthSelect = widget.NewSelect(b.months(), func(selected string) {
+		b.month = selected
+		b.apply()
+	})
+	b.monthSelect.SetSelected(allMonths)
+
+	editBtn := widget.NewButton("✏ Editar", func() {
+		if r := b.current(); r != nil {
+			b.m.showRecordEditForm(r, b.win, b.changed)
+		}
+	})
+
+	deleteBtn := widget.NewButton("🗑 Excluir", func() {
+		if r := b.current(); r != nil {
+			b.m.confirmRecordDelete(r, b.win, b.changed)
+		}
+	})
+
+	closeBtn := widget.NewButton("✖ Fechar", func() {
+		b.win.Close()
+	})
+
+	top := container.NewBorder(nil, nil, widget.NewLabel("Mês:"), nil, b.monthSelect)
+	buttons := container.NewHBox(layout.NewSpacer(), deleteBtn, editBtn, closeBtn)
+
+	b.win.SetContent(container.NewBorder(top, buttons, nil, nil, b.table))
+	b.win.Resize(fyne.NewSize(recordsWidth, recordsHigh))
+	b.win.CenterOnScreen()
+	b.win.Show()
+}
+
+// reload reads every record from the repository, lists their months again
+// and reapplies filter and sort
+func (b *recordsBrowser) reload() error {
+	records, err := b.m.repo.Records()
+	if err != nil {
+		return err
+	}
+
+	b.all = records
+	if b.monthSelect != nil {
+		// a delete may empty a month and records saved meanwhile may start one
+		b.monthSelect.SetOptions(b.months())
+		if !slices.Contains(b.monthSelect.Options, b.month) {
+			b.month = allMonths
+			b.monthSelect.Selected = allMonths
+			b.monthSelect.Refresh()
+		}
+	}
+	b.apply()
+	return nil
+}
+
+// apply filters the records by the selected month and sorts them by the selected column
+func (b *recordsBrowser) apply() {
+	b.visible = b.visible[:0]
+	for _, r := range b.all {
+		if b.month == allMonths || r.Local().Format(layoutMonth) == b.month {
+			b.visible = append(b.visible, r)
+		}
+	}
+
+	sort.SliceStable(b.visible, func(i, j int) bool {
+		if b.sortAsc {
+			return recordLess(&b.visible[i], &b.visible[j], b.sortCol)
+		}
+		return recordLess(&b.visible[j], &b.visible[i], b.sortCol)
+	})
+
+	b.selected = -1
+	if b.table != nil {
+		b.table.UnselectAll()
+		b.table.Refresh()
+	}
+}
+
+// sortBy sorts by col, toggling the direction when col is already the sort column
+func (b *recordsBrowser) sortBy(col int) {
+	if b.sortCol == col {
+		b.sortAsc = !b.sortAsc
+	} else {
+		b.sortCol = col
+		b.sortAsc = true
+	}
+	b.apply()
+}
+
+func (b *recordsBrowser) headerText(col int) string {
+	if col != b.sortCol {
+		return recordColumns[col]
+	}
+	if b.sortAsc {
+		return recordColumns[col] + " ▲"
+	}
+	return recordColumns[col] + " ▼"
+}
+
+// months lists the months that have records, newest first
+func (b *recordsBrowser) months() []string {
+	seen := map[string]bool{}
+	months := []string{allMonths}
+	for _, r := range b.all {
+		month := r.Local().Format(layoutMonth)
+		if !seen[month] {
+			seen[month] = true
+			months = append(months, month)
+		}
+	}
+	return months
+}
+
+func (b *recordsBrowser) current() *store.PresenceRecord {
+	if b.selected < 0 || b.selected >= len(b.visible) {
+		dialog.ShowInformation("Registros", "Selecione um registro na tabela", b.win)
+		return nil
+	}
+	return &b.visible[b.selected]
+}
+
+// showRecordEditForm edits the response, area and observation of record in
+// a dialog over win, calling onSaved once the change is stored
+func (m *MainApp) showRecordEditForm(record *store.PresenceRecord, win fyne.Window, onSaved func()) {
+	edited := *record
+
+	areas, err := m.repo.Areas()
+	if err != nil {
+		dialog.ShowError(err, win)
+		return
+	}
+
+	areaSelect := widget.NewSelect(areas, func(selected string) {
+		edited.Area = selected
+	})
+	areaSelect.SetSelected(record.Area)
+
+	responseSelect := widget.NewSelect(m.responseNames(), func(selected string) {
+		edited.Response = selected
+		if rt, ok := m.responseType(selected); ok && !rt.RequiresArea {
+			areaSelect.ClearSelected()
+			areaSelect.Disable()
+			return
+		}
+		areaSelect.Enable()
+	})
+	responseSelect.SetSelected(record.Response)
+
+	observationEntry := widget.NewEntry()
+	observationEntry.SetText(record.Observation)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Data", widget.NewLabel(record.Local().Format(layoutBR+" 15:04"))),
+		widget.NewFormItem("Resposta", responseSelect),
+		widget.NewFormItem("Área", areaSelect),
+		widget.NewFormItem("Observação", observationEntry),
+	}
+
+	form := dialog.NewForm("Editar Registro", "💾 Salvar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		if rt, ok := m.responseType(edited.Response); ok && rt.RequiresArea && edited.Area == "" {
+			dialog.ShowInformation("Erro", "Você precisa selecionar um local", win)
+			return
+		}
+
+		edited.Observation = strings.TrimSpace(observationEntry.Text)
+		if err := m.repo.UpdateRecord(&edited); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		onSaved()
+	}, win)
+	form.Resize(fyne.NewSize(recordsWidth/2, 0))
+	form.Show()
+}
+
+// confirmRecordDelete asks before deleting record, calling onDeleted once it is gone
+func (m *MainApp) confirmRecordDelete(record *store.PresenceRecord, win fyne.Window, onDeleted func()) {
+	msg := fmt.Sprintf("Excluir o registro de %s (%s)?", record.Local().Format(layoutBR), record.Response)
+	dialog.ShowConfirm("Excluir Registro", msg, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		if err := m.repo.DeleteRecord(record.ID); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		onDeleted()
+	}, win)
+}
+
+// changed reloads the table and the main window report after an edit or delete
+func (b *recordsBrowser) changed() {
+	if err := b.reload(); err != nil {
+		dialog.ShowError(err, b.win)
+	}
+	b.m.refreshMainContent()
+}
+
+func recordCell(r *store.PresenceRecord, col int) string {
+	switch col {
+	case 0:
+		if r.Retroactive {
+			return r.Local().Format(layoutBR) + " ↺"
+		}
+		return r.Local().Format(layoutBR)
+	case 1:
+		return r.Time()
+	case 2:
+		return r.Response
+	case 3:
+		return r.Area
+	default:
+		return r.Observation
+	}
+}
+
+func recordLess(a, b *store.PresenceRecord, col int) bool {
+	switch col {
+	case 0:
+		return a.TakenAt.Before(b.TakenAt)
+	default:
+		return strings.ToLower(recordCell(a, col)) < strings.ToLower(recordCell(b, col))
+	}
+}
@@ -0,0 +1,134 @@
+package program
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// responseTypeRow holds the widgets editing one entry of the response catalog
+type responseTypeRow struct {
+	id           uint
+	name         *widget.Entry
+	icon         *widget.Entry
+	countsToGoal *widget.Check
+	requiresArea *widget.Check
+}
+
+func (r *responseTypeRow) value() store.ResponseType {
+	return store.ResponseType{
+		ID:               r.id,
+		Name:             strings.TrimSpace(r.name.Text),
+		Icon:             strings.TrimSpace(r.icon.Text),
+		CountsTowardGoal: r.countsToGoal.Checked,
+		RequiresArea:     r.requiresArea.Checked,
+	}
+}
+
+func (m *MainApp) showResponseTypeConfigForm(onComplete func()) {
+	types, err := m.repo.ResponseTypes()
+	if err != nil {
+		dialog.ShowError(err, m.win)
+		return
+	}
+
+	var rows []*responseTypeRow
+	formContainer := container.NewVBox()
+
+	var refreshForm func()
+	refreshForm = func() {
+		formContainer.Objects = nil
+		rows = []*responseTypeRow{}
+
+		for _, rt := range types {
+			row := &responseTypeRow{
+				id:           rt.ID,
+				name:         widget.NewEntry(),
+				icon:         widget.NewEntry(),
+				countsToGoal: widget.NewCheck("Meta", nil),
+				requiresArea: widget.NewCheck("Área", nil),
+			}
+			row.name.SetText(rt.Name)
+			row.name.SetPlaceHolder("Nome")
+			row.icon.SetText(rt.Icon)
+			row.icon.SetPlaceHolder("Ícone")
+			row.countsToGoal.SetChecked(rt.CountsTowardGoal)
+			row.requiresArea.SetChecked(rt.RequiresArea)
+
+			rows = append(rows, row)
+
+			delBtn := widget.NewButton("🗑", func(r *responseTypeRow) func() {
+				return func() {
+					types = types[:0]
+					for _, other := range rows {
+						if other != r {
+							types = append(types, other.value())
+						}
+					}
+					refreshForm()
+				}
+			}(row))
+
+			options := container.NewHBox(row.countsToGoal, row.requiresArea, delBtn)
+			iconBox := container.NewGridWrap(fyne.NewSize(48, row.icon.MinSize().Height), row.icon)
+			formContainer.Add(container.NewBorder(nil, nil, iconBox, options, row.name))
+		}
+
+		addBtn := widget.NewButton("➕ Adicionar tipo de resposta", func() {
+			types = types[:0]
+			for _, r := range rows {
+				types = append(types, r.value())
+			}
+			types = append(types, store.ResponseType{})
+			refreshForm()
+		})
+		formContainer.Add(addBtn)
+		formContainer.Refresh()
+	}
+
+	saveBtn := widget.NewButton("💾 Salvar", func() {
+		var newTypes []store.ResponseType
+		for _, r := range rows {
+			if rt := r.value(); rt.Name != "" {
+				newTypes = append(newTypes, rt)
+			}
+		}
+
+		if err := m.repo.SaveResponseTypes(newTypes); err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		if err := m.loadConfigFromDB(); err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		dialog.ShowInformation("Sucesso", "Tipos de resposta atualizados com sucesso", m.win)
+		onComplete()
+	})
+
+	cancelBtn := widget.NewButton("✖ Cancelar", func() {
+		onComplete()
+	})
+
+	buttons := container.NewHBox(layout.NewSpacer(), cancelBtn, saveBtn)
+
+	content := container.NewBorder(
+		widget.NewLabelWithStyle("Editar Tipos de Resposta", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		buttons, nil, nil,
+		container.NewVScroll(formContainer),
+	)
+
+	refreshForm()
+
+	m.win.SetContent(content)
+	m.win.Resize(fyne.NewSize(width, high))
+	m.win.Show()
+}
@@ -0,0 +1,54 @@
+package program
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+var summaryFormats = []option[store.SummaryFormat]{
+	{store.SummaryText, "Texto simples"},
+	{store.SummaryMarkdown, "Tabela Markdown"},
+	{store.SummaryHTML, "HTML"},
+}
+
+// showCopySummaryForm asks for the format of the summary of the month shown
+// in the main window and puts it on the clipboard
+func (m *MainApp) showCopySummaryForm() {
+	formatGroup := widget.NewRadioGroup(labels(summaryFormats), nil)
+	formatGroup.Required = true
+	formatGroup.SetSelected(labelOf(summaryFormats, m.summaryFormat))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Mês", widget.NewLabel(store.MonthTitle(m.month))),
+		widget.NewFormItem("Formato", formatGroup),
+	}
+
+	form := dialog.NewForm("Copiar Resumo", "📋 Copiar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		m.summaryFormat = keyOf(summaryFormats, formatGroup.Selected)
+		m.app.Clipboard().SetContent(store.FormatSummary(m.report, m.summaryFormat))
+		dialog.ShowInformation("Copiar Resumo", "Resumo copiado para a área de transferência", m.win)
+	}, m.win)
+	form.Resize(fyne.NewSize(width, 0))
+	form.Show()
+}
+
+// copyCurrentSummary puts the summary of the current month on the clipboard
+// in the last format used, for the tray menu
+func (m *MainApp) copyCurrentSummary() error {
+	data, err := store.BuildReport(m.repo, time.Now(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	m.app.Clipboard().SetContent(store.FormatSummary(data, m.summaryFormat))
+	return nil
+}
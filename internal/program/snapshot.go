@@ -0,0 +1,219 @@
+package program
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+var backupIntervals = []option[int]{
+	{-1, "Somente ao iniciar"},
+	{1, "A cada hora"},
+	{6, "A cada 6 horas"},
+	{12, "A cada 12 horas"},
+	{0, "Diariamente"},
+}
+
+// startBackups takes a snapshot of the database now and then at the
+// configured interval, in the background
+func (m *MainApp) startBackups() {
+	m.backupTicker = time.NewTicker(store.DefaultBackupInterval)
+	m.scheduleBackups()
+
+	go func() {
+		m.takeSnapshot()
+		for range m.backupTicker.C {
+			m.takeSnapshot()
+		}
+	}()
+}
+
+// scheduleBackups applies the configured snapshot interval to the running schedule
+func (m *MainApp) scheduleBackups() {
+	if every := m.AppConfig.BackupEvery(); every > 0 {
+		m.backupTicker.Reset(every)
+		return
+	}
+	m.backupTicker.Stop()
+}
+
+// snapshotDir returns the folder of the automatic snapshots
+func (m *MainApp) snapshotDir() string {
+	return filepath.Join(filepath.Dir(m.dbPath), store.SnapshotDir)
+}
+
+// takeSnapshot writes a snapshot of the database and removes the ones the
+// retention no longer keeps
+func (m *MainApp) takeSnapshot() {
+	m.backupMu.Lock()
+	defer m.backupMu.Unlock()
+
+	now := time.Now()
+	if _, err := store.TakeSnapshot(m.repo, m.snapshotDir(), now); err != nil {
+		log.Printf("erro ao criar backup automático: %v", err)
+		return
+	}
+
+	if _, err := store.RotateSnapshots(m.snapshotDir(), now, store.DefaultRetention); err != nil {
+		log.Printf("erro ao remover backups antigos: %v", err)
+	}
+}
+
+// showSnapshotWindow lists the automatic snapshots with their record counts
+// and restores the selected one
+func (m *MainApp) showSnapshotWindow() {
+	win := m.app.NewWindow("Backups Automáticos")
+
+	var snapshots []store.SnapshotInfo
+	var counts []string
+	selected := -1
+
+	list := widget.NewList(
+		func() int { return len(snapshots) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			s := snapshots[id]
+			o.(*widget.Label).SetText(fmt.Sprintf("%s  —  %s  —  %s",
+				s.TakenAt.Format(layoutBR+" 15:04:05"), counts[id], formatSize(s.Size)))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) { selected = id }
+	list.OnUnselected = func(widget.ListItemID) { selected = -1 }
+
+	reload := func() {
+		var err error
+		if snapshots, err = store.ListSnapshots(m.snapshotDir()); err != nil {
+			dialog.ShowError(err, win)
+		}
+
+		counts = make([]string, len(snapshots))
+		for i, s := range snapshots {
+			n, err := store.SnapshotRecords(s.Path)
+			if err != nil {
+				counts[i] = "ilegível"
+				continue
+			}
+			counts[i] = fmt.Sprintf("%d registro(s)", n)
+		}
+
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+	}
+
+	intervalSelect := widget.NewSelect(labels(backupIntervals), func(choice string) {
+		hours := keyOf(backupIntervals, choice)
+		if hours == m.AppConfig.BackupInterval {
+			return
+		}
+		if err := m.repo.SaveBackupInterval(hours); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		m.AppConfig.BackupInterval = hours
+		m.scheduleBackups()
+	})
+	interval := labelOf(backupIntervals, m.AppConfig.BackupInterval)
+	if interval == "" {
+		interval = fmt.Sprintf("A cada %d horas", m.AppConfig.BackupInterval)
+		intervalSelect.Options = append(intervalSelect.Options, interval)
+	}
+	intervalSelect.SetSelected(interval)
+
+	backupBtn := widget.NewButton("💾 Fazer backup agora", func() {
+		m.takeSnapshot()
+		reload()
+	})
+
+	restoreBtn := widget.NewButton("♻ Restaurar", func() {
+		if selected < 0 {
+			return
+		}
+		s := snapshots[selected]
+		msg := fmt.Sprintf("Restaurar o backup de %s (%s)?\n\nO banco de dados atual será substituído; "+
+			"uma cópia dele é salva antes como um novo backup.", s.TakenAt.Format(layoutBR+" 15:04:05"), counts[selected])
+		dialog.ShowConfirm("Restaurar Backup Automático", msg, func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := m.restoreSnapshot(s); err != nil {
+				dialog.ShowError(err, win)
+			} else {
+				dialog.ShowInformation("Sucesso", "Backup restaurado com sucesso", win)
+			}
+			reload()
+		}, win)
+	})
+
+	closeBtn := widget.NewButton("✖ Fechar", func() {
+		win.Close()
+	})
+
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, widget.NewLabel("Backup automático:"), nil, intervalSelect),
+		widget.NewLabel("Backups salvos em "+m.snapshotDir()),
+	)
+	buttons := container.NewHBox(backupBtn, layout.NewSpacer(), restoreBtn, closeBtn)
+
+	reload()
+	win.SetContent(container.NewBorder(top, buttons, nil, nil, list))
+	win.Resize(fyne.NewSize(recordsWidth, recordsHigh))
+	win.CenterOnScreen()
+	win.Show()
+}
+
+// restoreSnapshot replaces the database with a snapshot, saving a snapshot
+// of the current database first, and reloads the window
+func (m *MainApp) restoreSnapshot(s store.SnapshotInfo) error {
+	m.backupMu.Lock()
+
+	if _, err := store.TakeSnapshot(m.repo, m.snapshotDir(), time.Now()); err != nil {
+		m.backupMu.Unlock()
+		return err
+	}
+
+	if err := m.repo.Close(); err != nil {
+		m.backupMu.Unlock()
+		return err
+	}
+
+	restoreErr := store.RestoreSnapshot(s.Path, m.dbPath)
+
+	// The database is reopened even when the restore failed and left it untouched
+	repo, err := store.NewSQLite(m.dbPath)
+	if err != nil {
+		m.backupMu.Unlock()
+		return err
+	}
+	m.repo = repo
+	m.backupMu.Unlock()
+
+	if restoreErr != nil {
+		return restoreErr
+	}
+
+	m.reloadAll()
+	m.scheduleBackups()
+	return nil
+}
+
+// formatSize formats a file size in bytes for display
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.0f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
@@ -0,0 +1,303 @@
+package program
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+const (
+	statsWidth = 600
+	statsHigh  = 560
+
+	chartWidth = 560
+	chartHigh  = 160
+	heatCell   = 8
+	heatGap    = 2
+)
+
+var (
+	colorOffice  = color.NRGBA{R: 0x2d, G: 0xa4, B: 0x4e, A: 0xff}
+	colorOther   = color.NRGBA{R: 0x3b, G: 0x82, B: 0xf6, A: 0xff}
+	colorHoliday = color.NRGBA{R: 0xf5, G: 0xc2, B: 0x42, A: 0xff}
+	colorEmpty   = color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x30}
+	colorGoal    = color.NRGBA{R: 0xdc, G: 0x26, B: 0x26, A: 0xff}
+
+	chartPalette = []color.NRGBA{
+		{R: 0x2d, G: 0xa4, B: 0x4e, A: 0xff},
+		{R: 0x3b, G: 0x82, B: 0xf6, A: 0xff},
+		{R: 0xf5, G: 0x9e, B: 0x0b, A: 0xff},
+		{R: 0x8b, G: 0x5c, B: 0xf6, A: 0xff},
+		{R: 0xec, G: 0x48, B: 0x99, A: 0xff},
+		{R: 0x14, G: 0xb8, B: 0xa6, A: 0xff},
+	}
+
+	monthInitials = []string{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"}
+)
+
+// showStatsWindow opens a window with charts of the attendance of a year
+func (m *MainApp) showStatsWindow() {
+	win := m.app.NewWindow("Estatísticas")
+	body := container.NewVBox()
+
+	draw := func(year int) {
+		content, err := m.buildStats(year)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		body.Objects = []fyne.CanvasObject{content}
+		body.Refresh()
+	}
+
+	yearSelect := widget.NewSelect(m.selectableYears(), func(selected string) {
+		if year, err := strconv.Atoi(selected); err == nil {
+			draw(year)
+		}
+	})
+	yearSelect.SetSelected(strconv.Itoa(m.month.Year()))
+
+	closeBtn := widget.NewButton("✖ Fechar", func() {
+		win.Close()
+	})
+
+	top := container.NewBorder(nil, nil, widget.NewLabel("Ano:"), nil, yearSelect)
+	buttons := container.NewHBox(layout.NewSpacer(), closeBtn)
+
+	win.SetContent(container.NewBorder(top, buttons, nil, nil, container.NewVScroll(body)))
+	win.Resize(fyne.NewSize(statsWidth, statsHigh))
+	win.CenterOnScreen()
+	win.Show()
+}
+
+// buildStats draws the monthly bar chart, the area split and the heatmap of year
+func (m *MainApp) buildStats(year int) (fyne.CanvasObject, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+
+	months, err := store.Summarize(m.repo, store.Months(from, to.AddDate(0, 0, -1)))
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := m.repo.RecordsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	hs, err := m.repo.Holidays(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	areas, err := m.repo.Areas()
+	if err != nil {
+		return nil, err
+	}
+
+	return container.NewVBox(
+		widget.NewLabelWithStyle("Dias presenciais por mês", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		monthBars(months),
+		legend([]color.Color{colorOffice, colorGoal}, []string{"Dias presenciais", "Meta"}),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Registros por área", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		areaBar(records, areas),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Calendário do ano", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		m.heatmap(from, to, records, hs),
+		legend([]color.Color{colorOffice, colorOther, colorHoliday, colorEmpty},
+			[]string{"Conta para a meta", "Outros registros", "Feriado", "Sem registro"}),
+	), nil
+}
+
+// monthBars draws one bar of goal days per month with a mark at the month's goal
+func monthBars(months []store.PeriodSummary) fyne.CanvasObject {
+	top := 1
+	for _, mo := range months {
+		top = max(top, mo.GoalDays, mo.Target)
+	}
+
+	const labelHigh = 16
+	plotHigh := float32(chartHigh - 2*labelHigh)
+	slot := float32(chartWidth) / float32(len(months))
+	barWidth := slot * 0.6
+
+	c := container.NewWithoutLayout()
+	baseline := canvas.NewLine(theme.Color(theme.ColorNameForeground))
+	baseline.Position1 = fyne.NewPos(0, labelHigh+plotHigh)
+	baseline.Position2 = fyne.NewPos(chartWidth, labelHigh+plotHigh)
+	c.Add(baseline)
+
+	for i, mo := range months {
+		x := float32(i)*slot + (slot-barWidth)/2
+		h := plotHigh * float32(mo.GoalDays) / float32(top)
+
+		bar := canvas.NewRectangle(colorOffice)
+		bar.Move(fyne.NewPos(x, labelHigh+plotHigh-h))
+		bar.Resize(fyne.NewSize(barWidth, h))
+		c.Add(bar)
+
+		if mo.Target > 0 {
+			goalY := labelHigh + plotHigh - plotHigh*float32(mo.Target)/float32(top)
+			goal := canvas.NewLine(colorGoal)
+			goal.StrokeWidth = 2
+			goal.Position1 = fyne.NewPos(x-2, goalY)
+			goal.Position2 = fyne.NewPos(x+barWidth+2, goalY)
+			c.Add(goal)
+		}
+
+		value := chartText(strconv.Itoa(mo.GoalDays))
+		value.Move(fyne.NewPos(x, labelHigh+plotHigh-h-labelHigh))
+		value.Resize(fyne.NewSize(barWidth, labelHigh))
+		c.Add(value)
+
+		name := chartText(monthInitials[mo.From.Month()-1])
+		name.Move(fyne.NewPos(x, labelHigh+plotHigh+2))
+		name.Resize(fyne.NewSize(barWidth, labelHigh))
+		c.Add(name)
+	}
+
+	return fixedSize(c, chartWidth, chartHigh)
+}
+
+// areaBar draws a single bar split by the share of records of each area
+func areaBar(records []store.PresenceRecord, areas []string) fyne.CanvasObject {
+	counts := map[string]int{}
+	total := 0
+	for _, r := range records {
+		if r.Area != "" {
+			counts[r.Area]++
+			total++
+		}
+	}
+	if total == 0 {
+		return widget.NewLabel("Nenhum registro com área neste ano")
+	}
+
+	for _, r := range records {
+		if r.Area != "" && !slices.Contains(areas, r.Area) {
+			areas = append(areas, r.Area)
+		}
+	}
+
+	const barHigh = 24
+	c := container.NewWithoutLayout()
+	var colors []color.Color
+	var names []string
+	x := float32(0)
+	for i, a := range areas {
+		if counts[a] == 0 {
+			continue
+		}
+		col := chartPalette[i%len(chartPalette)]
+		w := float32(chartWidth) * float32(counts[a]) / float32(total)
+
+		seg := canvas.NewRectangle(col)
+		seg.Move(fyne.NewPos(x, 0))
+		seg.Resize(fyne.NewSize(w, barHigh))
+		c.Add(seg)
+		x += w
+
+		colors = append(colors, col)
+		names = append(names, fmt.Sprintf("%s: %d (%.0f%%)", a, counts[a], float64(counts[a])*100/float64(total)))
+	}
+
+	return container.NewVBox(fixedSize(c, chartWidth, barHigh), legend(colors, names))
+}
+
+// heatmap draws one square per day of [from, to), a column per week and a
+// row per weekday starting on Monday
+func (m *MainApp) heatmap(from, to time.Time, records []store.PresenceRecord, hs []store.Holiday) fyne.CanvasObject {
+	office := map[string]bool{}
+	other := map[string]bool{}
+	for _, r := range records {
+		if rt, ok := m.responseType(r.Response); ok && rt.CountsTowardGoal {
+			office[r.Day] = true
+		} else {
+			other[r.Day] = true
+		}
+	}
+
+	holiday := map[string]bool{}
+	for _, h := range hs {
+		holiday[h.Day] = true
+	}
+
+	const labelHigh = 14
+	step := float32(heatCell + heatGap)
+	start, _ := store.PeriodWeek.Bounds(from)
+
+	c := container.NewWithoutLayout()
+	weeks := 0
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		week := int(math.Round(day.Sub(start).Hours()/24)) / 7
+		row := (int(day.Weekday()) + 6) % 7
+		weeks = max(weeks, week+1)
+
+		key := day.Format(store.LayoutISO)
+		var fill color.Color = colorEmpty
+		switch {
+		case office[key]:
+			fill = colorOffice
+		case other[key]:
+			fill = colorOther
+		case holiday[key]:
+			fill = colorHoliday
+		}
+
+		cell := canvas.NewRectangle(fill)
+		cell.CornerRadius = 2
+		cell.Move(fyne.NewPos(float32(week)*step, labelHigh+float32(row)*step))
+		cell.Resize(fyne.NewSize(heatCell, heatCell))
+		c.Add(cell)
+
+		if day.Day() == 1 {
+			name := canvas.NewText(store.MonthNames[day.Month()-1][:3], theme.Color(theme.ColorNameForeground))
+			name.TextSize = 9
+			name.Move(fyne.NewPos(float32(week)*step, 0))
+			c.Add(name)
+		}
+	}
+
+	return fixedSize(c, float32(weeks)*step, labelHigh+7*step)
+}
+
+// legend lays out colored squares followed by their names
+func legend(colors []color.Color, names []string) fyne.CanvasObject {
+	items := container.NewHBox()
+	for i, col := range colors {
+		square := canvas.NewRectangle(col)
+		square.SetMinSize(fyne.NewSize(10, 10))
+		items.Add(container.NewCenter(square))
+		items.Add(widget.NewLabel(names[i]))
+	}
+	return items
+}
+
+// chartText returns a small centered label for chart annotations
+func chartText(s string) *canvas.Text {
+	t := canvas.NewText(s, theme.Color(theme.ColorNameForeground))
+	t.TextSize = 10
+	t.Alignment = fyne.TextAlignCenter
+	return t
+}
+
+// fixedSize gives a container without layout the minimum size of its drawing
+func fixedSize(c *fyne.Container, w, h float32) fyne.CanvasObject {
+	spacer := canvas.NewRectangle(color.Transparent)
+	spacer.SetMinSize(fyne.NewSize(w, h))
+	return container.NewStack(spacer, c)
+}
@@ -0,0 +1,129 @@
+package program
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// summaryBrowser holds the state of the period summary window
+type summaryBrowser struct {
+	m     *MainApp
+	win   fyne.Window
+	table *widget.Table
+	rows  [][]string
+	year  int
+}
+
+// showSummaryWindow opens a window with the quarterly and yearly counts per
+// area and response type of the selected year
+func (m *MainApp) showSummaryWindow() {
+	b := &summaryBrowser{
+		m:    m,
+		win:  m.app.NewWindow("Resumo por Período"),
+		year: time.Now().Year(),
+	}
+
+	if err := b.reload(); err != nil {
+		dialog.ShowError(err, m.win)
+		return
+	}
+
+	b.table = widget.NewTableWithHeaders(
+		func() (int, int) { return len(b.rows) - 1, len(b.rows[0]) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(b.rows[id.Row+1][id.Col])
+		},
+	)
+	b.table.ShowHeaderColumn = false
+	b.table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		if id.Col >= 0 && id.Col < len(b.rows[0]) {
+			o.(*widget.Label).SetText(b.rows[0][id.Col])
+		}
+	}
+	b.resizeColumns()
+
+	yearSelect := widget.NewSelect(m.selectableYears(), func(selected string) {
+		year, err := strconv.Atoi(selected)
+		if err != nil {
+			return
+		}
+		b.year = year
+		if err := b.reload(); err != nil {
+			dialog.ShowError(err, b.win)
+			return
+		}
+		b.resizeColumns()
+		b.table.Refresh()
+	})
+	yearSelect.SetSelected(strconv.Itoa(b.year))
+
+	exportBtn := widget.NewButton("💾 Exportar CSV", func() {
+		b.export()
+	})
+
+	closeBtn := widget.NewButton("✖ Fechar", func() {
+		b.win.Close()
+	})
+
+	top := container.NewBorder(nil, nil, widget.NewLabel("Ano:"), nil, yearSelect)
+	buttons := container.NewHBox(layout.NewSpacer(), exportBtn, closeBtn)
+
+	b.win.SetContent(container.NewBorder(top, buttons, nil, nil, b.table))
+	b.win.Resize(fyne.NewSize(recordsWidth, recordsHigh/2))
+	b.win.CenterOnScreen()
+	b.win.Show()
+}
+
+// reload aggregates the quarters and the total of the selected year
+func (b *summaryBrowser) reload() error {
+	summaries, err := store.Summarize(b.m.repo, store.Quarters(b.year, time.Local))
+	if err != nil {
+		return err
+	}
+
+	areas, err := b.m.repo.Areas()
+	if err != nil {
+		return err
+	}
+
+	b.rows = store.SummaryTable(summaries, areas, b.m.responseTypes)
+	return nil
+}
+
+func (b *summaryBrowser) resizeColumns() {
+	for col, title := range b.rows[0] {
+		b.table.SetColumnWidth(col, float32(max(60, 9*len([]rune(title)))))
+	}
+}
+
+// export saves the summary table as a CSV file chosen by the user
+func (b *summaryBrowser) export() {
+	dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		filePath := writer.URI().Path()
+		_ = writer.Close()
+
+		if !strings.HasSuffix(filePath, ".csv") {
+			filePath += ".csv"
+		}
+
+		if err := store.WriteTableCSV(filePath, b.rows, excelCSV); err != nil {
+			dialog.ShowError(err, b.win)
+			return
+		}
+
+		dialog.ShowInformation("Sucesso", "Resumo exportado com sucesso", b.win)
+	}, b.win)
+}
@@ -0,0 +1,242 @@
+package program
+
+import (
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+const newTemplate = "➕ Novo modelo"
+
+// template looks up a loaded report template by ID
+func (m *MainApp) template(id uint) (store.ReportTemplate, bool) {
+	for _, t := range m.templates {
+		if id != 0 && t.ID == id {
+			return t, true
+		}
+	}
+	return store.ReportTemplate{}, false
+}
+
+// templateNames returns the names of the loaded report templates
+func (m *MainApp) templateNames() []string {
+	names := make([]string, 0, len(m.templates))
+	for _, t := range m.templates {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// templateByName looks up a loaded report template by name
+func (m *MainApp) templateByName(name string) (store.ReportTemplate, bool) {
+	for _, t := range m.templates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return store.ReportTemplate{}, false
+}
+
+// showTemplateWindow opens the report template editor, previewing the
+// template with the month shown in the main window as it is typed
+func (m *MainApp) showTemplateWindow() {
+	win := m.app.NewWindow("Modelos de Relatório")
+
+	current := store.ReportTemplate{Body: store.DefaultTemplate}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Nome do modelo")
+
+	preview := widget.NewLabel("")
+	preview.Wrapping = fyne.TextWrapWord
+
+	bodyEntry := widget.NewMultiLineEntry()
+	bodyEntry.TextStyle = fyne.TextStyle{Monospace: true}
+	bodyEntry.SetMinRowsVisible(14)
+	bodyEntry.OnChanged = func(text string) {
+		out, err := store.RenderTemplate(text, m.report)
+		if err != nil {
+			preview.SetText("⚠️ " + err.Error())
+			return
+		}
+		preview.SetText(out)
+	}
+
+	mainCheck := widget.NewCheck("Usar na janela principal", nil)
+
+	var templateSelect *widget.Select
+	load := func(tpl store.ReportTemplate) {
+		current = tpl
+		nameEntry.SetText(tpl.Name)
+		bodyEntry.SetText(tpl.Body)
+		bodyEntry.OnChanged(tpl.Body)
+		mainCheck.SetChecked(tpl.ID != 0 && tpl.ID == m.AppConfig.MainTemplateID)
+	}
+
+	options := func() []string {
+		return append(m.templateNames(), newTemplate)
+	}
+
+	templateSelect = widget.NewSelect(options(), func(selected string) {
+		if tpl, ok := m.templateByName(selected); ok {
+			load(tpl)
+			return
+		}
+		load(store.ReportTemplate{Body: store.DefaultTemplate})
+	})
+
+	reload := func(selected string) {
+		if err := m.loadConfigFromDB(); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		templateSelect.SetOptions(options())
+		templateSelect.SetSelected(selected)
+	}
+
+	saveBtn := widget.NewButton("💾 Salvar", func() {
+		tpl := store.ReportTemplate{ID: current.ID, Name: nameEntry.Text, Body: bodyEntry.Text}
+		if err := m.repo.SaveReportTemplate(&tpl); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+
+		mainID := m.AppConfig.MainTemplateID
+		switch {
+		case mainCheck.Checked:
+			mainID = tpl.ID
+		case mainID == tpl.ID:
+			mainID = 0
+		}
+		if err := m.repo.SaveMainTemplate(mainID); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+
+		reload(tpl.Name)
+		m.win.SetContent(m.buildMainContent())
+		dialog.ShowInformation("Sucesso", "Modelo salvo com sucesso", win)
+	})
+
+	deleteBtn := widget.NewButton("🗑 Excluir", func() {
+		if current.ID == 0 {
+			return
+		}
+		dialog.ShowConfirm("Excluir Modelo", "Excluir o modelo "+current.Name+"?", func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := m.repo.DeleteReportTemplate(current.ID); err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			reload(newTemplate)
+			m.win.SetContent(m.buildMainContent())
+		}, win)
+	})
+
+	closeBtn := widget.NewButton("✖ Fechar", func() {
+		win.Close()
+	})
+
+	help := widget.NewLabel("Campos: .MonthTitle, .Records (.Date, .Weekday, .Time, .Icon, .Response, .Area, " +
+		".Observation), .Goal (.Done, .MonthDone, .Target, .Remaining, .Reached, .Period, .Description, .Note), " +
+		".Forecast (.Needed, .Available, .PerWeek, .LastDay), .ByArea e " +
+		".ByResponse (.Name, .Count), .Holidays (.Date, .Name). Funções: seq, upper, lower, join, pad, reverse")
+	help.Wrapping = fyne.TextWrapWord
+
+	editor := container.NewBorder(
+		container.NewVBox(
+			container.NewBorder(nil, nil, widget.NewLabel("Modelo:"), nil, templateSelect),
+			container.NewBorder(nil, nil, widget.NewLabel("Nome:"), mainCheck, nameEntry),
+		),
+		help, nil, nil,
+		container.NewHSplit(bodyEntry, container.NewVScroll(preview)),
+	)
+	buttons := container.NewHBox(layout.NewSpacer(), deleteBtn, saveBtn, closeBtn)
+
+	if len(m.templates) > 0 {
+		templateSelect.SetSelected(m.templates[0].Name)
+		if tpl, ok := m.template(m.AppConfig.MainTemplateID); ok {
+			templateSelect.SetSelected(tpl.Name)
+		}
+	} else {
+		templateSelect.SetSelected(newTemplate)
+	}
+
+	win.SetContent(container.NewBorder(nil, buttons, nil, nil, editor))
+	win.Resize(fyne.NewSize(recordsWidth+160, recordsHigh+60))
+	win.CenterOnScreen()
+	win.Show()
+}
+
+// showTemplateExportForm renders a report template for the month shown in
+// the main window and saves the result to a file
+func (m *MainApp) showTemplateExportForm() {
+	if len(m.templates) == 0 {
+		dialog.ShowInformation("Modelos de Relatório", "Nenhum modelo cadastrado", m.win)
+		return
+	}
+
+	templateSelect := widget.NewSelect(m.templateNames(), nil)
+	templateSelect.SetSelected(m.templates[0].Name)
+	if tpl, ok := m.template(m.AppConfig.MainTemplateID); ok {
+		templateSelect.SetSelected(tpl.Name)
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Modelo", templateSelect),
+		widget.NewFormItem("Mês", widget.NewLabel(store.MonthTitle(m.month))),
+	}
+
+	month := m.month
+	form := dialog.NewForm("Exportar Relatório por Modelo", "💾 Exportar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		tpl, found := m.templateByName(templateSelect.Selected)
+		if !found {
+			return
+		}
+
+		data, err := store.BuildReport(m.repo, month, time.Now())
+		if err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		text, err := store.RenderTemplate(tpl.Body, data)
+		if err != nil {
+			dialog.ShowError(err, m.win)
+			return
+		}
+
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			defer func(writer fyne.URIWriteCloser) {
+				if err := writer.Close(); err != nil {
+					dialog.ShowError(err, m.win)
+				}
+			}(writer)
+
+			if _, err := writer.Write([]byte(strings.TrimRight(text, "\n") + "\n")); err != nil {
+				dialog.ShowError(err, m.win)
+				return
+			}
+
+			dialog.ShowInformation("Sucesso", "Relatório exportado com sucesso", m.win)
+		}, m.win)
+	}, m.win)
+	form.Resize(fyne.NewSize(width, 0))
+	form.Show()
+}
@@ -0,0 +1,58 @@
+package program
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dyammarcano/presencial/internal/store"
+)
+
+// showXLSXExportForm asks for the months to export to Excel and then for the file to write
+func (m *MainApp) showXLSXExportForm() {
+	fromEntry := newDayEntry(time.Date(m.month.Year(), time.January, 1, 0, 0, 0, 0, m.month.Location()))
+	toEntry := newDayEntry(m.month)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Do mês de", fromEntry),
+		widget.NewFormItem("Até o mês de", toEntry),
+	}
+
+	form := dialog.NewForm("Exportar Dados (XLSX)", "💾 Exportar", "✖ Cancelar", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		from, to := selectedDay(fromEntry), selectedDay(toEntry)
+		if to.Before(from) {
+			dialog.ShowInformation("Erro", "A data final precisa ser igual ou posterior à inicial", m.win)
+			return
+		}
+
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			filePath := writer.URI().Path()
+			_ = writer.Close()
+
+			if !strings.HasSuffix(filePath, ".xlsx") {
+				filePath += ".xlsx"
+			}
+
+			count, err := store.ExportXLSX(m.repo, filePath, from, to)
+			if err != nil {
+				dialog.ShowError(err, m.win)
+				return
+			}
+
+			dialog.ShowInformation("Sucesso", fmt.Sprintf("%d registro(s) exportado(s) com sucesso", count), m.win)
+		}, m.win)
+	}, m.win)
+	form.Resize(fyne.NewSize(width, 0))
+	form.Show()
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// BackupVersion is the format version of the backups written by this build
+	BackupVersion = 1
+
+	backupFormat = "presencial-backup"
+)
+
+// ErrInvalidBackup is returned when a backup file is not a backup, was
+// written by a newer build or holds inconsistent data
+var ErrInvalidBackup = errors.New("backup inválido")
+
+// Backup is the full content of the database: the application identity and
+// its language, interaction and config, the records and every catalog.
+// Format and Version identify the envelope, SchemaVersion the database it was
+// taken from
+type Backup struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	SchemaVersion int       `json:"schemaVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+
+	App             App              `json:"app"`
+	Records         []PresenceRecord `json:"records"`
+	ResponseTypes   []ResponseType   `json:"responseTypes"`
+	Holidays        []Holiday        `json:"holidays"`
+	GoalChanges     []GoalChange     `json:"goalChanges"`
+	ReportTemplates []ReportTemplate `json:"reportTemplates"`
+	CalendarRules   []CalendarRule   `json:"calendarRules"`
+}
+
+// ExportBackup writes the full content of the database to a backup file
+func ExportBackup(repo Repository, filePath string) error {
+	b, err := repo.Backup()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar backup: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+	return nil
+}
+
+// ReadBackup reads and validates a backup file
+func ReadBackup(filePath string) (*Backup, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	var b Backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+
+	if err := ValidateBackup(&b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// ValidateBackup checks the envelope of a backup and the consistency of its
+// data, so a restore never starts from a file it cannot apply completely
+func ValidateBackup(b *Backup) error {
+	switch {
+	case b.Format != backupFormat:
+		return fmt.Errorf("%w: arquivo não é um backup do aplicativo", ErrInvalidBackup)
+	case b.Version < 1 || b.Version > BackupVersion:
+		return fmt.Errorf("%w: versão %d não suportada (até %d)", ErrInvalidBackup, b.Version, BackupVersion)
+	case b.SchemaVersion > latestSchemaVersion():
+		return fmt.Errorf("%w (versão %d, suportada até %d)", ErrSchemaTooNew, b.SchemaVersion, latestSchemaVersion())
+	case b.App.AppID == uuid.Nil:
+		return fmt.Errorf("%w: identificação do aplicativo ausente", ErrInvalidBackup)
+	}
+
+	cfg := b.App.AppConfig
+	if err := ValidateGoal(cfg.DefaultGoal, cfg.Mode(), cfg.Period()); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+
+	if err := ValidateRecords(b.Records); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+
+	ids := map[uint]bool{}
+	slots := map[string]bool{}
+	for i, r := range b.Records {
+		slot := fmt.Sprintf("%s/%d", r.Date(), r.Slot)
+		if ids[r.ID] || slots[slot] {
+			return fmt.Errorf("%w: registro duplicado na posição %d", ErrInvalidBackup, i)
+		}
+		if r.ID != 0 {
+			ids[r.ID] = true
+		}
+		slots[slot] = true
+	}
+
+	names := map[string]bool{}
+	for _, t := range b.ResponseTypes {
+		if t.Name == "" || names[t.Name] {
+			return fmt.Errorf("%w: %w: %q", ErrInvalidBackup, ErrInvalidResponseType, t.Name)
+		}
+		names[t.Name] = true
+	}
+
+	for i := range b.Holidays {
+		if err := validateHoliday(&b.Holidays[i]); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+		}
+	}
+
+	for _, g := range b.GoalChanges {
+		if _, err := time.Parse(LayoutISO, g.Since); err != nil {
+			return fmt.Errorf("%w: histórico de metas com data %q", ErrInvalidBackup, g.Since)
+		}
+	}
+
+	names = map[string]bool{}
+	mainTemplate := cfg.MainTemplateID == 0
+	for i := range b.ReportTemplates {
+		tpl := &b.ReportTemplates[i]
+		if err := ValidateTemplate(tpl); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+		}
+		if names[tpl.Name] {
+			return fmt.Errorf("%w: %w: nome %q repetido", ErrInvalidBackup, ErrInvalidTemplate, tpl.Name)
+		}
+		names[tpl.Name] = true
+		mainTemplate = mainTemplate || tpl.ID == cfg.MainTemplateID
+	}
+	if !mainTemplate {
+		return fmt.Errorf("%w: modelo da janela principal não encontrado", ErrInvalidBackup)
+	}
+
+	for i := range b.CalendarRules {
+		if err := ValidateCalendarRule(&b.CalendarRules[i]); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+		}
+	}
+
+	return nil
+}
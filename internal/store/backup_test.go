@@ -0,0 +1,90 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBackupRestoreRoundTrip(t *testing.T) {
+	loc := useSaoPaulo(t)
+	src := newTestStore(t)
+
+	day := time.Date(2026, time.January, 31, 23, 30, 0, 0, loc)
+	saveDay(t, src, day, "Presencial", "Remoto")
+	saveDay(t, src, day.AddDate(0, 0, 3), "Férias")
+
+	edited := dayRecords(t, src, day)[0]
+	edited.Observation = "reunião"
+	if err := src.UpdateRecord(&edited); err != nil {
+		t.Fatalf("UpdateRecord: %v", err)
+	}
+
+	if err := src.SaveHoliday(&Holiday{Day: "2026-02-16", Name: "Carnaval"}); err != nil {
+		t.Fatalf("SaveHoliday: %v", err)
+	}
+	if err := src.SaveCalendarRules([]CalendarRule{
+		{Target: RuleResponse, Value: "Presencial", Pattern: "escritório"},
+		{Target: RuleArea, Value: "CT", Pattern: `\bCT$`, Regex: true},
+	}); err != nil {
+		t.Fatalf("SaveCalendarRules: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.json")
+	if err := ExportBackup(src, path); err != nil {
+		t.Fatalf("ExportBackup: %v", err)
+	}
+	b, err := ReadBackup(path)
+	if err != nil {
+		t.Fatalf("ReadBackup: %v", err)
+	}
+
+	dst := newTestStore(t)
+	saveDay(t, dst, day.AddDate(0, 1, 0), "Remoto")
+	if err := dst.Restore(b); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	want, err := src.Backup()
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	got, err := dst.Backup()
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		section func(b *Backup) any
+	}{
+		{"schema version", func(b *Backup) any { return b.SchemaVersion }},
+		{"app", func(b *Backup) any {
+			return []any{b.App.AppID, b.App.Name, b.App.Language.Title, b.App.Interaction.AreaOptions, b.App.AppConfig.DefaultGoal, b.App.AppConfig.GoalMode}
+		}},
+		{"records", func(b *Backup) any {
+			var list []string
+			for _, r := range b.Records {
+				list = append(list, fmt.Sprintf("%d %s #%d %s %s %s %s %q r%d %s",
+					r.ID, r.Date(), r.Slot, r.Local().Format(time.RFC3339), r.TimeZone, r.Response, r.Area, r.Observation,
+					r.Revision, r.UpdatedAt.UTC().Format(time.RFC3339)))
+			}
+			return list
+		}},
+		{"response types", func(b *Backup) any { return b.ResponseTypes }},
+		{"holidays", func(b *Backup) any { return b.Holidays }},
+		{"goal changes", func(b *Backup) any { return b.GoalChanges }},
+		{"report templates", func(b *Backup) any { return b.ReportTemplates }},
+		{"calendar rules", func(b *Backup) any { return b.CalendarRules }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if g, w := tt.section(got), tt.section(want); !reflect.DeepEqual(g, w) {
+				t.Errorf("restored %v, want %v", g, w)
+			}
+		})
+	}
+}
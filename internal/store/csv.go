@@ -0,0 +1,142 @@
+package store
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Record fields a configured header can refer to
+const (
+	FieldDate        = "Date"
+	FieldTime        = "Time"
+	FieldResponse    = "Response"
+	FieldArea        = "Area"
+	FieldObservation = "Observation"
+)
+
+// fieldAliases maps normalized header titles to the record field they name
+var fieldAliases = map[string]string{
+	"data":        FieldDate,
+	"date":        FieldDate,
+	"dia":         FieldDate,
+	"hora":        FieldTime,
+	"time":        FieldTime,
+	"horario":     FieldTime,
+	"resposta":    FieldResponse,
+	"response":    FieldResponse,
+	"tipo":        FieldResponse,
+	"area":        FieldArea,
+	"local":       FieldArea,
+	"observacao":  FieldObservation,
+	"observation": FieldObservation,
+	"obs":         FieldObservation,
+}
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "ê", "e", "í", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ú", "u", "ç", "c",
+)
+
+// HeaderField returns the record field named by a configured header, matching
+// Portuguese and English titles regardless of case and accents
+func HeaderField(header string) (string, bool) {
+	key := accents.Replace(strings.ToLower(strings.TrimSpace(header)))
+	field, ok := fieldAliases[key]
+	return field, ok
+}
+
+// CSVFormat describes how a CSV file is written
+type CSVFormat struct {
+	Delimiter rune
+	// BOM prefixes the file with a UTF-8 byte order mark so Excel detects the encoding
+	BOM bool
+	// DateLayout formats the Date column, LayoutISO when empty
+	DateLayout string
+}
+
+// ExportCSV writes the records of the days in [from, to] to a CSV file whose
+// columns follow the configured headers, in their order and with their
+// titles. Headers that name no record field produce empty columns. It
+// returns the number of records written
+func ExportCSV(repo Repository, filePath string, format CSVFormat, from, to time.Time) (int, error) {
+	headers, err := repo.Headers()
+	if err != nil {
+		return 0, err
+	}
+	if len(headers) == 0 {
+		return 0, fmt.Errorf("nenhum header configurado para exportação")
+	}
+
+	records, err := repo.RecordsBetween(StartOfDay(from), StartOfDay(to).AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+	slices.Reverse(records)
+
+	layout := format.DateLayout
+	if layout == "" {
+		layout = LayoutISO
+	}
+
+	rows := [][]string{headers}
+	for _, r := range records {
+		row := make([]string, len(headers))
+		for i, h := range headers {
+			field, _ := HeaderField(h)
+			row[i] = recordValue(&r, field, layout)
+		}
+		rows = append(rows, row)
+	}
+
+	if err := WriteTableCSV(filePath, rows, format); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// WriteTableCSV writes rows to a CSV file in the given format
+func WriteTableCSV(filePath string, rows [][]string, format CSVFormat) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo: %w", err)
+	}
+	defer f.Close()
+
+	if format.BOM {
+		if _, err := f.WriteString("\uFEFF"); err != nil {
+			return fmt.Errorf("erro ao salvar arquivo: %w", err)
+		}
+	}
+
+	w := csv.NewWriter(f)
+	if format.Delimiter != 0 {
+		w.Comma = format.Delimiter
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+	return f.Close()
+}
+
+// recordValue returns the text of field for r
+func recordValue(r *PresenceRecord, field, dateLayout string) string {
+	switch field {
+	case FieldDate:
+		return r.Local().Format(dateLayout)
+	case FieldTime:
+		return r.Time()
+	case FieldResponse:
+		return r.Response
+	case FieldArea:
+		return r.Area
+	case FieldObservation:
+		return r.Observation
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,255 @@
+package store
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// csvDelimiters are the delimiters ReadCSV tries, in order of preference
+var csvDelimiters = []rune{';', ',', '\t', '|'}
+
+// csvDateLayouts are the date formats accepted by a CSV import
+var csvDateLayouts = []string{
+	layoutBR,
+	LayoutISO,
+	"02/01/06",
+	"02-01-2006",
+	"02.01.2006",
+	"2006/01/02",
+	"2/1/2006",
+}
+
+// csvTimeLayouts are the time formats accepted by a CSV import
+var csvTimeLayouts = []string{layoutTime, "15:04", "15h04"}
+
+// CSVRowError is a problem found in one line of a CSV import
+type CSVRowError struct {
+	Line int
+	Err  string
+}
+
+func (e CSVRowError) Error() string {
+	return fmt.Sprintf("linha %d: %s", e.Line, e.Err)
+}
+
+// CSVFile is the content of a CSV file read for import
+type CSVFile struct {
+	Delimiter rune
+	Rows      [][]string
+	// Lines holds the line of the file each row starts at, blank lines are not rows
+	Lines []int
+}
+
+// ReadCSV reads every row of a CSV file, skipping a UTF-8 byte order mark.
+// A zero delimiter is detected from the first lines
+func ReadCSV(filePath string, delimiter rune) (*CSVFile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\uFEFF"))
+
+	if delimiter == 0 {
+		delimiter = DetectDelimiter(string(data))
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	file := &CSVFile{Delimiter: delimiter}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao processar CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		file.Rows = append(file.Rows, row)
+		file.Lines = append(file.Lines, line)
+	}
+	return file, nil
+}
+
+// DetectDelimiter returns the delimiter that splits the first lines of a CSV
+// text into the same number of columns, preferring the one giving the most
+func DetectDelimiter(text string) rune {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var sample []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			sample = append(sample, line)
+		}
+		if len(sample) == 10 {
+			break
+		}
+	}
+
+	best, bestColumns := csvDelimiters[0], 1
+	for _, d := range csvDelimiters {
+		columns := -1
+		for _, line := range sample {
+			n := strings.Count(line, string(d)) + 1
+			if columns == -1 {
+				columns = n
+			} else if n != columns {
+				columns = 0
+				break
+			}
+		}
+		if columns > bestColumns {
+			best, bestColumns = d, columns
+		}
+	}
+	return best
+}
+
+// CSVMapping assigns a record field to each column of a CSV file, an empty
+// field ignores the column
+type CSVMapping []string
+
+// DefaultCSVMapping maps each column to the field its title names. Columns
+// with an unknown title fall back to the configured header at the same
+// position, the order CSV exports use
+func DefaultCSVMapping(titles, headers []string) CSVMapping {
+	mapping := make(CSVMapping, len(titles))
+	used := map[string]bool{}
+	for i, title := range titles {
+		field, ok := HeaderField(title)
+		if !ok && i < len(headers) {
+			field, ok = HeaderField(headers[i])
+		}
+		if ok && !used[field] {
+			mapping[i] = field
+			used[field] = true
+		}
+	}
+	return mapping
+}
+
+// IsHeaderRow reports whether a CSV row is a title row, that is whether any
+// of its cells names a record field
+func IsHeaderRow(row []string) bool {
+	for _, cell := range row {
+		if _, ok := HeaderField(cell); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCSVRecords converts CSV rows into presence records taken in the local
+// time zone following mapping. lines holds the line number of each row,
+// used in the errors of rows that cannot be converted. Rows without values
+// are skipped. It fails only when the mapping itself is invalid
+func ParseCSVRecords(rows [][]string, lines []int, mapping CSVMapping) ([]PresenceRecord, []CSVRowError, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var records []PresenceRecord
+	var errs []CSVRowError
+
+	tz := LocalTimeZone()
+	loc := loadLocation(tz)
+	for i, row := range rows {
+		line := lines[i]
+		values := map[string]string{}
+		blank := true
+		for col, field := range mapping {
+			if field == "" || col >= len(row) {
+				continue
+			}
+			values[field] = strings.TrimSpace(row[col])
+			if values[field] != "" {
+				blank = false
+			}
+		}
+		if blank {
+			continue
+		}
+
+		takenAt, err := parseCSVTimestamp(values[FieldDate], values[FieldTime], loc)
+		if err != nil {
+			errs = append(errs, CSVRowError{Line: line, Err: err.Error()})
+			continue
+		}
+		if values[FieldResponse] == "" {
+			errs = append(errs, CSVRowError{Line: line, Err: "resposta ausente"})
+			continue
+		}
+
+		records = append(records, PresenceRecord{
+			TakenAt:     takenAt,
+			TimeZone:    tz,
+			Response:    values[FieldResponse],
+			Area:        values[FieldArea],
+			Observation: values[FieldObservation],
+		})
+	}
+
+	return records, errs, nil
+}
+
+// Validate checks that the date and the response columns are mapped
+func (m CSVMapping) Validate() error {
+	if !m.has(FieldDate) || !m.has(FieldResponse) {
+		return fmt.Errorf("mapeie as colunas de data e de resposta")
+	}
+	return nil
+}
+
+func (m CSVMapping) has(field string) bool {
+	for _, f := range m {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCSVTimestamp combines a date and an optional time in any of the
+// accepted formats. A date cell may carry the time itself
+func parseCSVTimestamp(date, clock string, loc *time.Location) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, fmt.Errorf("data ausente")
+	}
+
+	if clock == "" {
+		if d, c, ok := strings.Cut(date, " "); ok {
+			date, clock = d, strings.TrimSpace(c)
+		} else if t, err := time.Parse(time.RFC3339, date); err == nil {
+			return t.In(loc), nil
+		}
+	}
+
+	var day time.Time
+	var err error
+	for _, layout := range csvDateLayouts {
+		if day, err = time.ParseInLocation(layout, date, loc); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("data inválida: %s", date)
+	}
+
+	if clock == "" {
+		return day, nil
+	}
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.Parse(layout, clock); err == nil {
+			y, m, d := day.Date()
+			return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("hora inválida: %s", clock)
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrWeekend is returned when a record targets a Saturday or Sunday
+	ErrWeekend = errors.New("não é possível registrar presença em fim de semana")
+	// ErrDayRecorded is returned when the target day already has a record and
+	// the save was not told how to resolve the conflict
+	ErrDayRecorded = errors.New("já existe um registro para este dia")
+)
+
+// StartOfDay returns midnight of t's day in t's location
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// SameDay reports whether a and b fall on the same calendar day in a's location
+func SameDay(a, b time.Time) bool {
+	return StartOfDay(a).Equal(StartOfDay(b.In(a.Location())))
+}
+
+// AtDay returns day combined with the clock time of clock, in day's location
+func AtDay(day, clock time.Time) time.Time {
+	clock = clock.In(day.Location())
+	y, m, d := day.Date()
+	return time.Date(y, m, d, clock.Hour(), clock.Minute(), clock.Second(), 0, day.Location())
+}
+
+// IsWeekend reports whether t falls on a Saturday or Sunday
+func IsWeekend(t time.Time) bool {
+	wd := t.Weekday()
+	return wd == time.Saturday || wd == time.Sunday
+}
+
+// CheckDay validates that a new record may target day, which must be a weekday
+func CheckDay(day time.Time) error {
+	if IsWeekend(day) {
+		return ErrWeekend
+	}
+	return nil
+}
+
+// WorkingDays returns the weekdays in [from, to) that are not holidays
+func WorkingDays(from, to time.Time, holidays []Holiday) []time.Time {
+	off := map[string]bool{}
+	for _, h := range holidays {
+		off[h.Day] = true
+	}
+
+	var days []time.Time
+	for day := StartOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !IsWeekend(day) && !off[day.Format(LayoutISO)] {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// MonthNames holds the Portuguese month names, January first
+var MonthNames = []string{
+	"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+	"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
+}
+
+var weekdayNames = []string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"}
+
+// MonthTitle names the month of t, e.g. "Outubro de 2026"
+func MonthTitle(t time.Time) string {
+	return MonthNames[t.Month()-1] + " de " + strconv.Itoa(t.Year())
+}
+
+// WeekdayName returns the abbreviated Portuguese name of t's weekday
+func WeekdayName(t time.Time) string {
+	return weekdayNames[t.Weekday()]
+}
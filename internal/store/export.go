@@ -0,0 +1,170 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ExportJSON exports all presence records to a JSON file
+func ExportJSON(repo Repository, filePath string) error {
+	records, err := repo.Records()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar dados: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+
+	return nil
+}
+
+// ImportJSON imports presence records from a JSON file, adding new days and
+// keeping the local record of conflicting ones
+func ImportJSON(repo Repository, filePath string) error {
+	records, err := ReadJSON(filePath)
+	if err != nil {
+		return err
+	}
+
+	plan, err := PlanImport(repo, records)
+	if err != nil {
+		return err
+	}
+
+	if _, err := repo.ApplyImport(plan); err != nil {
+		return fmt.Errorf("erro ao finalizar importação: %w", err)
+	}
+
+	return nil
+}
+
+// ReadJSON reads and validates the presence records of a JSON file
+func ReadJSON(filePath string) ([]PresenceRecord, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("erro ao processar JSON: %w", err)
+	}
+
+	records := make([]PresenceRecord, len(raw))
+	for i, item := range raw {
+		if err := json.Unmarshal(item, &records[i]); err != nil {
+			return nil, fmt.Errorf("registro inválido na posição %d: %w", i, err)
+		}
+	}
+
+	if err := ValidateRecords(records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// ValidateRecords checks that every record has the mandatory fields
+func ValidateRecords(records []PresenceRecord) error {
+	for i, record := range records {
+		if record.TakenAt.IsZero() || record.Response == "" {
+			return fmt.Errorf("registro inválido na posição %d: campos obrigatórios ausentes", i)
+		}
+	}
+	return nil
+}
+
+// recordJSON is the interchange format of a presence record. Date and Time
+// are kept for compatibility with files exported before records carried a
+// timestamp, TakenAt and TimeZone take precedence when present
+type recordJSON struct {
+	ID          uint       `json:"ID,omitempty"`
+	Date        string     `json:"Date,omitempty"`
+	Time        string     `json:"Time,omitempty"`
+	TakenAt     *time.Time `json:"TakenAt,omitempty"`
+	TimeZone    string     `json:"TimeZone,omitempty"`
+	Slot        int        `json:"Slot,omitempty"`
+	Response    string     `json:"Response"`
+	Observation string     `json:"Observation"`
+	Area        string     `json:"Area"`
+	Retroactive bool       `json:"Retroactive,omitempty"`
+	UpdatedAt   *time.Time `json:"UpdatedAt,omitempty"`
+	Revision    int        `json:"Revision,omitempty"`
+}
+
+// MarshalJSON writes the record with both the timestamp and the legacy date and time fields
+func (r PresenceRecord) MarshalJSON() ([]byte, error) {
+	out := recordJSON{
+		ID:          r.ID,
+		TimeZone:    r.TimeZone,
+		Slot:        r.Slot,
+		Response:    r.Response,
+		Observation: r.Observation,
+		Area:        r.Area,
+		Retroactive: r.Retroactive,
+		Revision:    r.Revision,
+	}
+
+	if !r.UpdatedAt.IsZero() {
+		out.UpdatedAt = &r.UpdatedAt
+	}
+	if !r.TakenAt.IsZero() {
+		local := r.Local()
+		out.Date = local.Format(LayoutISO)
+		out.Time = local.Format(layoutTime)
+		out.TakenAt = &local
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON reads a record, accepting the legacy date and time string fields
+func (r *PresenceRecord) UnmarshalJSON(data []byte) error {
+	var in recordJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*r = PresenceRecord{
+		ID:          in.ID,
+		TimeZone:    in.TimeZone,
+		Slot:        in.Slot,
+		Response:    in.Response,
+		Observation: in.Observation,
+		Area:        in.Area,
+		Retroactive: in.Retroactive,
+		Revision:    in.Revision,
+	}
+	if in.UpdatedAt != nil {
+		r.UpdatedAt = *in.UpdatedAt
+	}
+
+	switch {
+	case in.TakenAt != nil:
+		r.TakenAt = *in.TakenAt
+	case in.Date != "":
+		takenAt, err := parseLegacyTimestamp(in.Date, in.Time, loadLocation(in.TimeZone))
+		if err != nil {
+			return fmt.Errorf("formato de data inválido: %s", in.Date)
+		}
+		r.TakenAt = takenAt
+	}
+
+	return nil
+}
+
+// parseLegacyTimestamp combines the legacy date and time strings in the given location
+func parseLegacyTimestamp(date, clock string, loc *time.Location) (time.Time, error) {
+	if clock == "" {
+		return time.ParseInLocation(LayoutISO, date, loc)
+	}
+	return time.ParseInLocation(LayoutISO+" "+layoutTime, date+" "+clock, loc)
+}
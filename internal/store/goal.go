@@ -0,0 +1,305 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// GoalMode selects how the goal value is interpreted
+type GoalMode string
+
+// GoalPeriod is the window the goal is evaluated over
+type GoalPeriod string
+
+const (
+	// GoalDays requires a number of office days per period
+	GoalDays GoalMode = "days"
+	// GoalPercent requires a percentage of the working days of the period
+	GoalPercent GoalMode = "percent"
+
+	PeriodWeek    GoalPeriod = "week"
+	PeriodMonth   GoalPeriod = "month"
+	PeriodQuarter GoalPeriod = "quarter"
+	PeriodYear    GoalPeriod = "year"
+)
+
+// maxGoalDays caps the number of days a goal may require per period
+var maxGoalDays = map[GoalPeriod]int{
+	PeriodWeek:    5,
+	PeriodMonth:   24,
+	PeriodQuarter: 66,
+	PeriodYear:    260,
+}
+
+// ValidateGoal checks that goal is acceptable for mode and period
+func ValidateGoal(goal int, mode GoalMode, period GoalPeriod) error {
+	limit, ok := maxGoalDays[period]
+	if !ok {
+		return fmt.Errorf("%w: período %q", ErrInvalidGoal, period)
+	}
+
+	switch mode {
+	case GoalDays:
+	case GoalPercent:
+		limit = 100
+	default:
+		return fmt.Errorf("%w: modo %q", ErrInvalidGoal, mode)
+	}
+
+	if goal < 1 || goal > limit {
+		return fmt.Errorf("%w: informe um valor entre 1 e %d", ErrInvalidGoal, limit)
+	}
+	return nil
+}
+
+// Mode returns the goal mode, defaulting to days for configs saved before modes existed
+func (c *AppConfig) Mode() GoalMode {
+	if c.GoalMode == "" {
+		return GoalDays
+	}
+	return c.GoalMode
+}
+
+// Period returns the goal period, defaulting to the calendar month
+func (c *AppConfig) Period() GoalPeriod {
+	if c.GoalPeriod == "" {
+		return PeriodMonth
+	}
+	return c.GoalPeriod
+}
+
+// Bounds returns the [from, to) window of period containing t. Weeks start on Monday
+func (p GoalPeriod) Bounds(t time.Time) (time.Time, time.Time) {
+	day := StartOfDay(t)
+	switch p {
+	case PeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		from := day.AddDate(0, 0, -offset)
+		return from, from.AddDate(0, 0, 7)
+	case PeriodQuarter:
+		month := time.Month((int(day.Month())-1)/3*3 + 1)
+		from := time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+		return from, from.AddDate(0, 3, 0)
+	case PeriodYear:
+		from := time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+		return from, from.AddDate(1, 0, 0)
+	default:
+		from := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return from, from.AddDate(0, 1, 0)
+	}
+}
+
+// Label names the current period, e.g. "nesta semana"
+func (p GoalPeriod) Label() string {
+	switch p {
+	case PeriodWeek:
+		return "nesta semana"
+	case PeriodQuarter:
+		return "neste trimestre"
+	case PeriodYear:
+		return "neste ano"
+	default:
+		return "neste mês"
+	}
+}
+
+// Per names the period as a rate, e.g. "por semana"
+func (p GoalPeriod) Per() string {
+	switch p {
+	case PeriodWeek:
+		return "por semana"
+	case PeriodQuarter:
+		return "por trimestre"
+	case PeriodYear:
+		return "por ano"
+	default:
+		return "por mês"
+	}
+}
+
+// Text names the period starting at from, using Label for the current period
+func (p GoalPeriod) Text(from time.Time) string {
+	if cur, _ := p.Bounds(time.Now()); cur.Equal(from) {
+		return p.Label()
+	}
+
+	switch p {
+	case PeriodWeek:
+		return "na semana de " + from.Format(layoutBR)
+	case PeriodQuarter:
+		return fmt.Sprintf("no %dº trimestre de %d", (int(from.Month())-1)/3+1, from.Year())
+	case PeriodYear:
+		return fmt.Sprintf("em %d", from.Year())
+	default:
+		return "em " + MonthTitle(from)
+	}
+}
+
+// Describe summarizes the goal, e.g. "2 dia(s) por semana" or "40% dos dias úteis por mês"
+func (c *AppConfig) Describe() string {
+	if c.Mode() == GoalPercent {
+		return fmt.Sprintf("%d%% dos dias úteis %s", c.DefaultGoal, c.Period().Per())
+	}
+	return fmt.Sprintf("%d dia(s) %s", c.DefaultGoal, c.Period().Per())
+}
+
+// Target returns the number of office days the goal requires in [from, to)
+func (c *AppConfig) Target(from, to time.Time, holidays []Holiday) int {
+	if c.Mode() == GoalPercent {
+		working := len(WorkingDays(from, to, holidays))
+		return int(math.Ceil(float64(working) * float64(c.DefaultGoal) / 100))
+	}
+	return AdjustedGoal(c.DefaultGoal, from, to, holidays)
+}
+
+// GoalStatus is the progress toward the goal in one period
+type GoalStatus struct {
+	From   time.Time
+	To     time.Time
+	Target int
+	Done   int
+}
+
+// Reached reports whether the period already has the required office days
+func (g GoalStatus) Reached() bool {
+	return g.Done >= g.Target
+}
+
+// Remaining returns how many office days are still required
+func (g GoalStatus) Remaining() int {
+	return max(g.Target-g.Done, 0)
+}
+
+// EvaluateGoal computes the progress toward the configured goal in the period containing t
+func EvaluateGoal(repo Repository, cfg *AppConfig, t time.Time) (GoalStatus, error) {
+	from, to := cfg.Period().Bounds(t)
+	status := GoalStatus{From: from, To: to}
+
+	hs, err := repo.Holidays(from, to)
+	if err != nil {
+		return status, err
+	}
+
+	records, err := repo.RecordsBetween(from, to)
+	if err != nil {
+		return status, err
+	}
+
+	types, err := repo.ResponseTypes()
+	if err != nil {
+		return status, err
+	}
+
+	status.Target = cfg.Target(from, to, hs)
+	status.Done = CountGoalDays(records, types)
+	return status, nil
+}
+
+// Forecast projects what is still needed to reach the goal of a period
+type Forecast struct {
+	// Needed is the number of office days still required
+	Needed int
+	// Available lists the remaining working days of the period that have no record yet
+	Available []time.Time
+}
+
+// ForecastGoal lists the days still available to reach status from now on.
+// Weekends, holidays and days already recorded are not available
+func ForecastGoal(repo Repository, status GoalStatus, now time.Time) (Forecast, error) {
+	forecast := Forecast{Needed: status.Remaining()}
+
+	from := maxTime(status.From, StartOfDay(now))
+	if !from.Before(status.To) {
+		return forecast, nil
+	}
+
+	hs, err := repo.Holidays(from, status.To)
+	if err != nil {
+		return forecast, err
+	}
+
+	records, err := repo.RecordsBetween(from, status.To)
+	if err != nil {
+		return forecast, err
+	}
+
+	recorded := map[string]bool{}
+	for _, r := range records {
+		recorded[r.Date()] = true
+	}
+
+	for _, day := range WorkingDays(from, status.To, hs) {
+		if !recorded[day.Format(LayoutISO)] {
+			forecast.Available = append(forecast.Available, day)
+		}
+	}
+	return forecast, nil
+}
+
+// Reachable reports whether enough days remain to reach the goal
+func (f Forecast) Reachable() bool {
+	return f.Needed <= len(f.Available)
+}
+
+// Weeks counts the distinct weeks the available days fall in
+func (f Forecast) Weeks() int {
+	weeks := map[time.Time]bool{}
+	for _, day := range f.Available {
+		start, _ := PeriodWeek.Bounds(day)
+		weeks[start] = true
+	}
+	return len(weeks)
+}
+
+// PerWeek returns the office days per remaining week that reach the goal
+func (f Forecast) PerWeek() int {
+	weeks := f.Weeks()
+	if weeks == 0 {
+		return f.Needed
+	}
+	return (f.Needed + weeks - 1) / weeks
+}
+
+// LastDay returns the last available day, the zero time when none remains
+func (f Forecast) LastDay() time.Time {
+	if len(f.Available) == 0 {
+		return time.Time{}
+	}
+	return f.Available[len(f.Available)-1]
+}
+
+// CountGoalDays counts the distinct days of records whose response counts
+// toward the goal. A split day with two such parts still counts as one day
+func CountGoalDays(records []PresenceRecord, types []ResponseType) int {
+	counts := map[string]bool{}
+	for _, t := range types {
+		counts[t.Name] = t.CountsTowardGoal
+	}
+
+	days := map[string]bool{}
+	for _, r := range records {
+		if counts[r.Response] {
+			days[r.Date()] = true
+		}
+	}
+	return len(days)
+}
+
+// AdjustedGoal scales a goal set for a full period by the share of its
+// weekdays that are working days, so holidays lower the number of office
+// days required. The result is never below one while working days remain
+func AdjustedGoal(goal int, from, to time.Time, holidays []Holiday) int {
+	weekdays := len(WorkingDays(from, to, nil))
+	if weekdays == 0 {
+		return goal
+	}
+
+	working := len(WorkingDays(from, to, holidays))
+	if working == 0 {
+		return 0
+	}
+
+	adjusted := int(math.Round(float64(goal) * float64(working) / float64(weekdays)))
+	return max(adjusted, 1)
+}
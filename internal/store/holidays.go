@@ -0,0 +1,24 @@
+package store
+
+import (
+	"time"
+)
+
+// ImportHolidaysICS imports every day covered by the events of an iCalendar
+// file as a holiday named after the event summary. Yearly holidays without
+// an end are imported up to the end of next year
+func ImportHolidaysICS(repo Repository, filePath string) (int, error) {
+	events, err := ReadICS(filePath, time.Date(time.Now().Year()+2, time.January, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		return 0, err
+	}
+
+	var list []Holiday
+	for _, e := range events {
+		for _, day := range e.Days() {
+			list = append(list, Holiday{Day: day.Format(LayoutISO), Name: e.Summary, Source: HolidayImported})
+		}
+	}
+
+	return repo.ImportHolidays(list)
+}
@@ -0,0 +1,242 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dyammarcano/presencial/internal/ical"
+)
+
+// icsProdID identifies the application in the calendars it writes
+const icsProdID = "-//Presencial//Registro de Presença//PT"
+
+// Calendar rule targets
+const (
+	RuleResponse = "response"
+	RuleArea     = "area"
+)
+
+// ExportICS writes the records of the days in [from, to] to an iCalendar
+// file, one all-day event per record. It returns the number of events written
+func ExportICS(repo Repository, filePath string, from, to time.Time) (int, error) {
+	app, err := repo.LoadApp()
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := repo.RecordsBetween(StartOfDay(from), StartOfDay(to).AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+	slices.Reverse(records)
+
+	now := time.Now()
+	events := make([]ical.Event, len(records))
+	for i := range records {
+		events[i] = recordEvent(app.AppID, &records[i], now)
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao criar arquivo: %w", err)
+	}
+	defer f.Close()
+
+	if err := ical.Write(f, icsProdID, events); err != nil {
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+	return len(events), nil
+}
+
+// recordEvent describes a record as an all-day event. Its UID depends only
+// on the application and the day and slot of the record, so exporting again
+// updates the events a calendar already has instead of duplicating them. The
+// revision of the record becomes the SEQUENCE that makes the update win
+func recordEvent(appID uuid.UUID, r *PresenceRecord, stamp time.Time) ical.Event {
+	summary := r.Response
+	if r.Area != "" {
+		summary += " - " + r.Area
+	}
+
+	modified := r.UpdatedAt
+	if modified.IsZero() {
+		modified = r.TakenAt
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("%s-%d@%s", r.Date(), r.Slot, appID),
+		Summary:     summary,
+		Description: r.Observation,
+		Location:    r.Area,
+		Categories:  []string{r.Response},
+		Start:       StartOfDay(r.Local()),
+		AllDay:      true,
+		Stamp:       stamp,
+		Modified:    modified,
+		Sequence:    r.Revision,
+	}
+}
+
+// ReadICS reads the events of an iCalendar file, one per occurrence of the
+// recurring ones, leaving out the cancelled ones. Series without an end
+// repeat until the day before until
+func ReadICS(filePath string, until time.Time) ([]ical.Event, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+	defer f.Close()
+
+	events, err := ical.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+	return ical.Expand(events, until), nil
+}
+
+// ValidateCalendarRule trims a calendar import rule and checks that it is
+// complete and that its regular expression compiles
+func ValidateCalendarRule(rule *CalendarRule) error {
+	rule.Value = strings.TrimSpace(rule.Value)
+	rule.Pattern = strings.TrimSpace(rule.Pattern)
+
+	switch {
+	case rule.Target != RuleResponse && rule.Target != RuleArea:
+		return fmt.Errorf("%w: destino %q desconhecido", ErrInvalidCalendarRule, rule.Target)
+	case rule.Value == "":
+		return fmt.Errorf("%w: valor ausente", ErrInvalidCalendarRule)
+	case rule.Pattern == "":
+		return fmt.Errorf("%w: padrão ausente para %q", ErrInvalidCalendarRule, rule.Value)
+	}
+
+	if _, err := rule.matcher(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// matcher returns the function that reports whether an event text matches the rule
+func (rule *CalendarRule) matcher() (func(string) bool, error) {
+	if rule.Regex {
+		re, err := regexp.Compile("(?i)" + rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expressão %q: %w", ErrInvalidCalendarRule, rule.Pattern, err)
+		}
+		return re.MatchString, nil
+	}
+
+	keyword := foldText(rule.Pattern)
+	return func(text string) bool {
+		return strings.Contains(foldText(text), keyword)
+	}, nil
+}
+
+// foldText lowers the case and removes the accents of a text for keyword matching
+func foldText(text string) string {
+	return accents.Replace(strings.ToLower(text))
+}
+
+// CalendarMatch is the result of applying the calendar import rules to the
+// events of a file
+type CalendarMatch struct {
+	// Records holds one record per day of each event a response rule matched
+	Records []PresenceRecord
+	// Matched is the number of events a response rule matched
+	Matched int
+	// Skipped is the number of days of the matched events left out for
+	// falling on a weekend or holiday
+	Skipped int
+	// Unmatched lists, once each, the summaries of the other events
+	Unmatched []string
+}
+
+// MatchEvents turns the events whose summary or location matches a response
+// rule into records, one per day the event covers. The first matching
+// response rule gives the response and the first matching area rule, if any,
+// the area. The event description becomes the observation. All-day events
+// are recorded at midnight, timed events at their start time. Days refused by
+// CheckDay and holidays are skipped, so an event spanning a week records
+// only its working days
+func MatchEvents(events []ical.Event, rules []CalendarRule, holidays []Holiday) (*CalendarMatch, error) {
+	type compiled struct {
+		target, value string
+		match         func(string) bool
+	}
+
+	var matchers []compiled
+	for i := range rules {
+		rule := rules[i]
+		if err := ValidateCalendarRule(&rule); err != nil {
+			return nil, err
+		}
+		match, _ := rule.matcher()
+		matchers = append(matchers, compiled{rule.Target, rule.Value, match})
+	}
+
+	first := func(target string, e *ical.Event) string {
+		for _, m := range matchers {
+			if m.target == target && (m.match(e.Summary) || e.Location != "" && m.match(e.Location)) {
+				return m.value
+			}
+		}
+		return ""
+	}
+
+	off := map[string]bool{}
+	for _, h := range holidays {
+		off[h.Day] = true
+	}
+
+	result := &CalendarMatch{}
+	unmatched := map[string]bool{}
+	for _, e := range events {
+		response := first(RuleResponse, &e)
+		if response == "" {
+			if summary := strings.TrimSpace(e.Summary); !unmatched[summary] {
+				unmatched[summary] = true
+				result.Unmatched = append(result.Unmatched, summary)
+			}
+			continue
+		}
+		result.Matched++
+
+		if !e.AllDay {
+			e.Start, e.End = e.Start.In(time.Local), e.End.In(time.Local)
+		}
+
+		area := first(RuleArea, &e)
+		for i, day := range e.Days() {
+			if CheckDay(day) != nil || off[day.Format(LayoutISO)] {
+				result.Skipped++
+				continue
+			}
+
+			takenAt := day
+			if i == 0 && !e.AllDay {
+				takenAt = e.Start
+			}
+
+			result.Records = append(result.Records, PresenceRecord{
+				TakenAt:     takenAt,
+				TimeZone:    LocalTimeZone(),
+				Response:    response,
+				Area:        area,
+				Observation: strings.TrimSpace(e.Description),
+			})
+		}
+	}
+
+	if err := ValidateRecords(result.Records); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dyammarcano/presencial/internal/ical"
+)
+
+func TestMatchEvents(t *testing.T) {
+	loc := useSaoPaulo(t)
+	rules := []CalendarRule{
+		{Target: RuleResponse, Value: "Presencial", Pattern: "escritório"},
+		{Target: RuleArea, Value: "CT", Pattern: `\bCT$`, Regex: true},
+	}
+	holidays := []Holiday{{Day: "2026-02-16", Name: "Carnaval"}}
+
+	allDay := func(summary string, from time.Time, days int) ical.Event {
+		return ical.Event{Summary: summary, Start: from, End: from.AddDate(0, 0, days), AllDay: true}
+	}
+
+	tests := []struct {
+		name   string
+		events []ical.Event
+		// want lists the records as "day time response area"
+		want        []string
+		wantMatched int
+		wantSkipped int
+		wantMissing []string
+	}{
+		{
+			name:        "timed event keeps its start time",
+			events:      []ical.Event{{Summary: "Escritório CT", Start: time.Date(2026, time.February, 10, 12, 0, 0, 0, time.UTC)}},
+			want:        []string{"2026-02-10 09:00:00 Presencial CT"},
+			wantMatched: 1,
+		},
+		{
+			name:        "week long event skips the weekend and the holiday",
+			events:      []ical.Event{allDay("Escritório", time.Date(2026, time.February, 12, 0, 0, 0, 0, loc), 6)},
+			want:        []string{"2026-02-12 00:00:00 Presencial ", "2026-02-13 00:00:00 Presencial ", "2026-02-17 00:00:00 Presencial "},
+			wantMatched: 1,
+			wantSkipped: 3,
+		},
+		{
+			name:        "event on a Saturday records nothing",
+			events:      []ical.Event{allDay("escritorio", time.Date(2026, time.February, 14, 0, 0, 0, 0, loc), 1)},
+			want:        []string{},
+			wantMatched: 1,
+			wantSkipped: 1,
+		},
+		{
+			name: "unmatched summaries are listed once",
+			events: []ical.Event{
+				allDay("Dentista", time.Date(2026, time.February, 10, 0, 0, 0, 0, loc), 1),
+				allDay(" Dentista ", time.Date(2026, time.February, 11, 0, 0, 0, 0, loc), 1),
+			},
+			want:        []string{},
+			wantMissing: []string{"Dentista"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MatchEvents(tt.events, rules, holidays)
+			if err != nil {
+				t.Fatalf("MatchEvents: %v", err)
+			}
+
+			got := []string{}
+			for _, r := range result.Records {
+				got = append(got, r.Date()+" "+r.Time()+" "+r.Response+" "+r.Area)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %q, want %q", got, tt.want)
+			}
+			if result.Matched != tt.wantMatched || result.Skipped != tt.wantSkipped {
+				t.Errorf("matched %d and skipped %d, want %d and %d", result.Matched, result.Skipped, tt.wantMatched, tt.wantSkipped)
+			}
+			if !reflect.DeepEqual(result.Unmatched, tt.wantMissing) {
+				t.Errorf("unmatched = %q, want %q", result.Unmatched, tt.wantMissing)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"strings"
+)
+
+// ImportStatus classifies an incoming record against the records already stored for its day
+type ImportStatus int
+
+const (
+	// ImportNew is a record for a day without records
+	ImportNew ImportStatus = iota
+	// ImportIdentical is a record whose response, area and observation are already stored for its day
+	ImportIdentical
+	// ImportConflict is a record for a day already recorded with something else
+	ImportConflict
+)
+
+// ImportAction decides what ApplyImport does with an incoming record
+type ImportAction int
+
+const (
+	// ImportKeepLocal leaves the stored records of the day untouched and drops the incoming one
+	ImportKeepLocal ImportAction = iota
+	// ImportTakeImported replaces the stored records of the day with the incoming one
+	ImportTakeImported
+	// ImportKeepBoth stores the incoming record in the next free slot of its day
+	ImportKeepBoth
+)
+
+// ImportItem is an incoming record with its classification and the action
+// chosen for it. Record never carries the ID it had in the source file
+type ImportItem struct {
+	Record PresenceRecord
+	Status ImportStatus
+	// Local holds the stored records of the same day
+	Local  []PresenceRecord
+	Action ImportAction
+}
+
+// ImportPlan is the preview of an import, one item per incoming record in file order
+type ImportPlan []ImportItem
+
+// Count returns how many items have the given status
+func (p ImportPlan) Count(status ImportStatus) int {
+	n := 0
+	for _, item := range p {
+		if item.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// SetConflicts applies action to every conflicting item
+func (p ImportPlan) SetConflicts(action ImportAction) {
+	for i := range p {
+		if p[i].Status == ImportConflict {
+			p[i].Action = action
+		}
+	}
+}
+
+// PlanImport compares incoming records with the stored ones and classifies
+// each of them. New records are added, identical ones skipped and conflicts
+// keep the local record until another action is chosen. Records repeated
+// inside the incoming set are compared with each other as well
+func PlanImport(repo Repository, records []PresenceRecord) (ImportPlan, error) {
+	if err := ValidateRecords(records); err != nil {
+		return nil, err
+	}
+
+	stored, err := repo.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	local := map[string][]PresenceRecord{}
+	for i := len(stored) - 1; i >= 0; i-- {
+		local[stored[i].Date()] = append(local[stored[i].Date()], stored[i])
+	}
+
+	incoming := map[string][]PresenceRecord{}
+	plan := make(ImportPlan, 0, len(records))
+	for _, r := range records {
+		// Incoming IDs belong to another database
+		r.ID = 0
+		r.ProfileID = 0
+		day := r.Date()
+
+		item := ImportItem{Record: r, Local: local[day], Action: ImportKeepBoth}
+		switch {
+		case containsSame(local[day], r) || containsSame(incoming[day], r):
+			item.Status, item.Action = ImportIdentical, ImportKeepLocal
+		case len(local[day]) > 0:
+			item.Status, item.Action = ImportConflict, ImportKeepLocal
+		}
+
+		incoming[day] = append(incoming[day], r)
+		plan = append(plan, item)
+	}
+
+	return plan, nil
+}
+
+// containsSame reports whether records holds one with the response, area and observation of r
+func containsSame(records []PresenceRecord, r PresenceRecord) bool {
+	for _, other := range records {
+		if sameRecord(other, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameRecord(a, b PresenceRecord) bool {
+	return a.Date() == b.Date() &&
+		a.Response == b.Response &&
+		strings.TrimSpace(a.Area) == strings.TrimSpace(b.Area) &&
+		strings.TrimSpace(a.Observation) == strings.TrimSpace(b.Observation)
+}
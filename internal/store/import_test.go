@@ -0,0 +1,144 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApplyImportConflicts(t *testing.T) {
+	loc := useSaoPaulo(t)
+	day1 := time.Date(2026, time.March, 9, 9, 0, 0, 0, loc)
+	day2 := day1.AddDate(0, 0, 1)
+
+	incoming := func(day time.Time, response string) PresenceRecord {
+		return PresenceRecord{TakenAt: day.Add(2 * time.Hour), TimeZone: LocalTimeZone(), Response: response, Area: "CT"}
+	}
+
+	tests := []struct {
+		name         string
+		local        []string
+		incoming     []PresenceRecord
+		action       ImportAction
+		wantStatus   []ImportStatus
+		wantImported int
+		// want lists the records of the first day, wantTotal counts every record
+		want      []string
+		wantTotal int
+	}{
+		{
+			name:         "new day is added",
+			local:        []string{"Presencial"},
+			incoming:     []PresenceRecord{incoming(day2, "Remoto")},
+			wantStatus:   []ImportStatus{ImportNew},
+			wantImported: 1,
+			want:         []string{"Presencial"},
+			wantTotal:    2,
+		},
+		{
+			name:       "identical record is skipped",
+			local:      []string{"Presencial"},
+			incoming:   []PresenceRecord{incoming(day1, "Presencial")},
+			action:     ImportTakeImported,
+			wantStatus: []ImportStatus{ImportIdentical},
+			want:       []string{"Presencial"},
+			wantTotal:  1,
+		},
+		{
+			name:       "conflict keeps the local record",
+			local:      []string{"Presencial"},
+			incoming:   []PresenceRecord{incoming(day1, "Remoto")},
+			action:     ImportKeepLocal,
+			wantStatus: []ImportStatus{ImportConflict},
+			want:       []string{"Presencial"},
+			wantTotal:  1,
+		},
+		{
+			name:         "conflict takes the imported record",
+			local:        []string{"Presencial"},
+			incoming:     []PresenceRecord{incoming(day1, "Remoto")},
+			action:       ImportTakeImported,
+			wantStatus:   []ImportStatus{ImportConflict},
+			wantImported: 1,
+			want:         []string{"Remoto"},
+			wantTotal:    1,
+		},
+		{
+			name:         "conflict keeps both records",
+			local:        []string{"Presencial"},
+			incoming:     []PresenceRecord{incoming(day1, "Remoto")},
+			action:       ImportKeepBoth,
+			wantStatus:   []ImportStatus{ImportConflict},
+			wantImported: 1,
+			want:         []string{"Presencial", "Remoto"},
+			wantTotal:    2,
+		},
+		{
+			name:         "every imported record of a replaced day is kept",
+			local:        []string{"Presencial"},
+			incoming:     []PresenceRecord{incoming(day1, "Remoto"), incoming(day1, "Férias")},
+			action:       ImportTakeImported,
+			wantStatus:   []ImportStatus{ImportConflict, ImportConflict},
+			wantImported: 2,
+			want:         []string{"Remoto", "Férias"},
+			wantTotal:    2,
+		},
+		{
+			name:         "imported record replaces a split day",
+			local:        []string{"Presencial", "Remoto"},
+			incoming:     []PresenceRecord{incoming(day1, "Férias")},
+			action:       ImportTakeImported,
+			wantStatus:   []ImportStatus{ImportConflict},
+			wantImported: 1,
+			want:         []string{"Férias"},
+			wantTotal:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			saveDay(t, s, day1, tt.local...)
+
+			plan, err := PlanImport(s, tt.incoming)
+			if err != nil {
+				t.Fatalf("PlanImport: %v", err)
+			}
+
+			status := []ImportStatus{}
+			for _, item := range plan {
+				status = append(status, item.Status)
+			}
+			if !reflect.DeepEqual(status, tt.wantStatus) {
+				t.Fatalf("status = %v, want %v", status, tt.wantStatus)
+			}
+
+			plan.SetConflicts(tt.action)
+			imported, err := s.ApplyImport(plan)
+			if err != nil {
+				t.Fatalf("ApplyImport: %v", err)
+			}
+			if imported != tt.wantImported {
+				t.Errorf("imported = %d, want %d", imported, tt.wantImported)
+			}
+
+			records := dayRecords(t, s, day1)
+			if got := responses(records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+			for i, r := range records {
+				if r.Slot != i {
+					t.Errorf("record %s in slot %d, want %d", r.Response, r.Slot, i)
+				}
+			}
+
+			all, err := s.Records()
+			if err != nil {
+				t.Fatalf("Records: %v", err)
+			}
+			if len(all) != tt.wantTotal {
+				t.Errorf("total records = %d, want %d", len(all), tt.wantTotal)
+			}
+		})
+	}
+}
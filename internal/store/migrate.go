@@ -0,0 +1,594 @@
+package store
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrSchemaTooNew is returned when the database was created by a newer build of the application
+var ErrSchemaTooNew = errors.New("banco de dados criado por uma versão mais nova do aplicativo")
+
+// SchemaVersion records each migration applied to the database
+type SchemaVersion struct {
+	Version     int `gorm:"primaryKey;autoIncrement:false"`
+	Description string
+	AppliedAt   time.Time
+}
+
+// TableName keeps the schema version table name singular
+func (SchemaVersion) TableName() string {
+	return "schema_version"
+}
+
+// migration is a single ordered schema step. Steps must tolerate databases
+// created before the schema was versioned, where part of the change may
+// already be present
+type migration struct {
+	version     int
+	description string
+	up          func(tx *gorm.DB) error
+}
+
+// migrations lists every schema step in the order it must be applied.
+// New steps are appended with the next version number and never edited
+// once released
+var migrations = []migration{
+	{1, "estrutura inicial", migrateInitialSchema},
+	{2, "registros com timestamp e fuso horário", migrateRecordTimestamps},
+	{3, "indicador de registro retroativo", migrateAddRecordColumns},
+	{4, "um registro por dia", migrateOneRecordPerDay},
+	{5, "catálogo de tipos de resposta", migrateResponseTypes},
+	{6, "calendário de feriados", migrateHolidays},
+	{7, "modos e períodos de meta", migrateGoalModes},
+	{8, "histórico de metas", migrateGoalHistory},
+	{9, "visualização em calendário", migrateCalendarView},
+	{10, "modelos de relatório", migrateReportTemplates},
+	{11, "backups automáticos", migrateBackupInterval},
+	{12, "regras de importação de calendário", migrateCalendarRules},
+	{13, "modelo padrão com previsão da meta", migrateDefaultTemplate},
+	{14, "revisão dos registros", migrateRecordRevisions},
+	{15, "remoção do registro de mesclagens", migrateDropRecordMerges},
+}
+
+// versionOneRecordPerDay is the step that turned days with several records into split days
+const versionOneRecordPerDay = 4
+
+// latestSchemaVersion is the schema version this build knows how to handle
+func latestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// migrate brings the database at path up to the latest schema version and
+// returns the version it was at. The database file is backed up before any
+// pending step runs, and all pending steps are applied in a single transaction
+func migrate(db *gorm.DB, path string) (int, error) {
+	if err := db.AutoMigrate(&SchemaVersion{}); err != nil {
+		return 0, fmt.Errorf("erro ao criar tabela de versão: %w", err)
+	}
+
+	var current int
+	if err := db.Model(&SchemaVersion{}).Select("COALESCE(MAX(version), 0)").Scan(&current).Error; err != nil {
+		return 0, fmt.Errorf("erro ao ler versão do banco de dados: %w", err)
+	}
+
+	latest := latestSchemaVersion()
+	if current > latest {
+		return current, fmt.Errorf("%w (versão %d, suportada até %d)", ErrSchemaTooNew, current, latest)
+	}
+	if current == latest {
+		return current, nil
+	}
+
+	if hasUserData(db) {
+		if err := backupBeforeMigration(db, path, current); err != nil {
+			return current, err
+		}
+	}
+
+	return current, db.Transaction(func(tx *gorm.DB) error {
+		for _, m := range migrations {
+			if m.version <= current {
+				continue
+			}
+
+			if err := m.up(tx); err != nil {
+				return fmt.Errorf("erro na migração %d (%s): %w", m.version, m.description, err)
+			}
+
+			if err := tx.Create(&SchemaVersion{
+				Version:     m.version,
+				Description: m.description,
+				AppliedAt:   time.Now(),
+			}).Error; err != nil {
+				return fmt.Errorf("erro ao registrar migração %d: %w", m.version, err)
+			}
+		}
+		return nil
+	})
+}
+
+// hasUserData reports whether the database holds any table besides the schema version
+func hasUserData(db *gorm.DB) bool {
+	var count int64
+	db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_version', 'sqlite_sequence')").
+		Scan(&count)
+	return count > 0
+}
+
+// backupBeforeMigration writes a consistent copy of the database next to it
+func backupBeforeMigration(db *gorm.DB, path string, version int) error {
+	backupPath := fmt.Sprintf("%s.v%d-%s.bak", path, version, time.Now().Format("20060102_150405"))
+	if err := db.Exec("VACUUM INTO ?", backupPath).Error; err != nil {
+		return fmt.Errorf("erro ao criar backup antes da migração: %w", err)
+	}
+	return nil
+}
+
+// appLanguageV1, appInteractionV1, appConfigV1 and appV1 are the application
+// tables of the first released version. Columns added later come with the
+// step that added them
+type appLanguageV1 struct {
+	ID          uint `gorm:"primarykey"`
+	CreatedAt   time.Time
+	WindowName  string
+	Title       string
+	Welcome     string
+	Goal        string
+	Report      string
+	Observation string
+	Area        string
+	Save        string
+	Cancel      string
+	Yes         string
+	No          string
+	Close       string
+	Error       string
+	Success     string
+	SuccessMsg  string
+	ErrorMsg    string
+	WarningMsg  string
+	Warning     string
+	Info        string
+}
+
+func (appLanguageV1) TableName() string { return "app_languages" }
+
+type appInteractionV1 struct {
+	ID          uint `gorm:"primarykey"`
+	ExtraLabel  string
+	AreaOptions string
+	Headers     string
+}
+
+func (appInteractionV1) TableName() string { return "app_interactions" }
+
+type appConfigV1 struct {
+	ID          uint `gorm:"primarykey"`
+	DefaultGoal int
+}
+
+func (appConfigV1) TableName() string { return "app_configs" }
+
+type appV1 struct {
+	ID            uint `gorm:"primarykey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	AppID         uuid.UUID
+	Name          string
+	Theme         string
+	LanguageID    uint
+	Language      appLanguageV1 `gorm:"foreignKey:LanguageID"`
+	InteractionID uint
+	Interaction   appInteractionV1 `gorm:"foreignKey:InteractionID"`
+	AppConfigID   uint
+	AppConfig     appConfigV1 `gorm:"foreignKey:AppConfigID"`
+}
+
+func (appV1) TableName() string { return "apps" }
+
+// presenceRecordV1 is the presence_records layout of the first released version,
+// when records still stored their date and time as strings
+type presenceRecordV1 struct {
+	ID          uint `gorm:"primaryKey"`
+	Date        string
+	Time        string
+	Response    string
+	Observation string
+	Area        string
+}
+
+func (presenceRecordV1) TableName() string { return "presence_records" }
+
+// presenceRecordV2 is the presence_records layout once records gained a timestamp
+type presenceRecordV2 struct {
+	ID          uint      `gorm:"primaryKey"`
+	TakenAt     time.Time `gorm:"index"`
+	TimeZone    string
+	Response    string
+	Observation string
+	Area        string
+}
+
+func (presenceRecordV2) TableName() string { return "presence_records" }
+
+// presenceRecordV3 is the presence_records layout once records were flagged retroactive
+type presenceRecordV3 struct {
+	ID          uint      `gorm:"primaryKey"`
+	TakenAt     time.Time `gorm:"index"`
+	TimeZone    string
+	Response    string
+	Observation string
+	Area        string
+	Retroactive bool
+}
+
+func (presenceRecordV3) TableName() string { return "presence_records" }
+
+// presenceRecordV4 holds the columns that identify the profile, day and slot of a record
+type presenceRecordV4 struct {
+	ID        uint `gorm:"primaryKey"`
+	ProfileID uint
+	Day       string
+	Slot      int
+}
+
+func (presenceRecordV4) TableName() string { return "presence_records" }
+
+// responseTypeV5 is the response catalog layout created by migrateResponseTypes
+type responseTypeV5 struct {
+	ID               uint   `gorm:"primarykey"`
+	Name             string `gorm:"uniqueIndex"`
+	Icon             string
+	CountsTowardGoal bool
+	RequiresArea     bool
+	Position         int
+}
+
+func (responseTypeV5) TableName() string { return "response_types" }
+
+// holidayV6 is the holiday table layout created by migrateHolidays
+type holidayV6 struct {
+	ID     uint   `gorm:"primarykey"`
+	Day    string `gorm:"index"`
+	Name   string
+	Source string
+}
+
+func (holidayV6) TableName() string { return "holidays" }
+
+// appConfigV7 holds the columns that select how the goal is evaluated
+type appConfigV7 struct {
+	ID         uint   `gorm:"primarykey"`
+	GoalMode   string `gorm:"default:days"`
+	GoalPeriod string `gorm:"default:month"`
+}
+
+func (appConfigV7) TableName() string { return "app_configs" }
+
+// goalChangeV8 is the goal history layout created by migrateGoalHistory
+type goalChangeV8 struct {
+	ID          uint   `gorm:"primarykey"`
+	Since       string `gorm:"uniqueIndex"`
+	DefaultGoal int
+	GoalMode    string
+	GoalPeriod  string
+}
+
+func (goalChangeV8) TableName() string { return "goal_changes" }
+
+// appConfigV8 holds the goal columns read to seed the goal history
+type appConfigV8 struct {
+	ID          uint
+	DefaultGoal int
+	GoalMode    string
+	GoalPeriod  string
+}
+
+func (appConfigV8) TableName() string { return "app_configs" }
+
+// appConfigV9 holds the column that selects the calendar view
+type appConfigV9 struct {
+	ID           uint `gorm:"primarykey"`
+	CalendarView bool
+}
+
+func (appConfigV9) TableName() string { return "app_configs" }
+
+// appConfigV10 holds the column that selects the report template of the main window
+type appConfigV10 struct {
+	ID             uint `gorm:"primarykey"`
+	MainTemplateID uint
+}
+
+func (appConfigV10) TableName() string { return "app_configs" }
+
+// reportTemplateV10 is the report template layout created by migrateReportTemplates
+type reportTemplateV10 struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"uniqueIndex"`
+	Body string
+}
+
+func (reportTemplateV10) TableName() string { return "report_templates" }
+
+// appConfigV11 holds the column that sets the interval of automatic snapshots
+type appConfigV11 struct {
+	ID             uint `gorm:"primarykey"`
+	BackupInterval int
+}
+
+func (appConfigV11) TableName() string { return "app_configs" }
+
+// calendarRuleV12 is the calendar rule layout created by migrateCalendarRules
+type calendarRuleV12 struct {
+	ID       uint `gorm:"primarykey"`
+	Target   string
+	Value    string
+	Pattern  string
+	Regex    bool
+	Position int
+}
+
+func (calendarRuleV12) TableName() string { return "calendar_rules" }
+
+// presenceRecordV14 holds the columns that track changes to a record
+type presenceRecordV14 struct {
+	ID        uint `gorm:"primaryKey"`
+	UpdatedAt time.Time
+	Revision  int
+}
+
+func (presenceRecordV14) TableName() string { return "presence_records" }
+
+// migrateInitialSchema creates the structures of the first released version
+func migrateInitialSchema(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&appLanguageV1{}, &appInteractionV1{}, &appV1{}, &appConfigV1{}); err != nil {
+		return err
+	}
+	return tx.AutoMigrate(&presenceRecordV1{})
+}
+
+// migrateRecordTimestamps converts the legacy date and time string columns of
+// presence_records into the taken_at timestamp and the time_zone name. Legacy
+// values are interpreted in the local zone, where they were originally taken
+func migrateRecordTimestamps(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&presenceRecordV2{}); err != nil {
+		return err
+	}
+
+	if !tx.Migrator().HasColumn(&presenceRecordV1{}, "date") {
+		return nil
+	}
+
+	var rows []presenceRecordV1
+	if err := tx.Select("id, date, time").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	zone := LocalTimeZone()
+	loc := loadLocation(zone)
+
+	for _, row := range rows {
+		takenAt, err := parseLegacyTimestamp(row.Date, row.Time, loc)
+		if err != nil {
+			return fmt.Errorf("registro %d com data inválida %q: %w", row.ID, row.Date, err)
+		}
+
+		if err := tx.Model(&presenceRecordV2{ID: row.ID}).
+			Updates(map[string]any{"taken_at": takenAt.UTC(), "time_zone": zone}).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, column := range []string{"date", "time"} {
+		if err := tx.Migrator().DropColumn(&presenceRecordV1{}, column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateAddRecordColumns adds the new presence record columns, which
+// default to their zero value on existing rows
+func migrateAddRecordColumns(tx *gorm.DB) error {
+	return tx.AutoMigrate(&presenceRecordV3{})
+}
+
+// migrateOneRecordPerDay assigns every record to the profile and day it
+// belongs to and enforces a single record per profile, day and slot. Days
+// with more than one record keep all of them as a split day, one slot per
+// record in the order they were taken, so no record is lost
+func migrateOneRecordPerDay(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&presenceRecordV4{}); err != nil {
+		return err
+	}
+
+	var profileID uint
+	tx.Model(&appV1{}).Select("id").Order("id").Limit(1).Scan(&profileID)
+
+	var records []presenceRecordV3
+	if err := tx.Order("taken_at, id").Find(&records).Error; err != nil {
+		return err
+	}
+
+	slots := map[string]int{}
+	for _, r := range records {
+		day := r.TakenAt.In(loadLocation(r.TimeZone)).Format(LayoutISO)
+		if err := tx.Model(&presenceRecordV4{ID: r.ID}).
+			Updates(map[string]any{"profile_id": profileID, "day": day, "slot": slots[day]}).Error; err != nil {
+			return err
+		}
+		slots[day]++
+	}
+
+	return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_record_day ON presence_records(profile_id, day, slot)").Error
+}
+
+// defaultResponseTypesV5 seeds the response catalog. Presencial and Remoto
+// keep the names records used before the catalog existed
+var defaultResponseTypesV5 = []responseTypeV5{
+	{Name: "Presencial", Icon: "🏢", CountsTowardGoal: true, RequiresArea: true},
+	{Name: "Remoto", Icon: "🏠"},
+	{Name: "Férias", Icon: "🌴"},
+	{Name: "Feriado", Icon: "🎉"},
+	{Name: "Licença médica", Icon: "🤒"},
+	{Name: "Folga", Icon: "🛌"},
+	{Name: "Viagem a trabalho", Icon: "✈️"},
+}
+
+// migrateResponseTypes creates the response catalog with its default entries
+func migrateResponseTypes(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&responseTypeV5{}); err != nil {
+		return err
+	}
+
+	var count int64
+	tx.Model(&responseTypeV5{}).Count(&count)
+	if count > 0 {
+		return nil
+	}
+
+	types := slices.Clone(defaultResponseTypesV5)
+	for i := range types {
+		types[i].Position = i
+	}
+	return tx.Create(&types).Error
+}
+
+// migrateHolidays creates the table of user-defined and imported holidays
+func migrateHolidays(tx *gorm.DB) error {
+	return tx.AutoMigrate(&holidayV6{})
+}
+
+// migrateGoalModes adds the goal mode and period. Existing goals keep their
+// meaning of a number of days per calendar month
+func migrateGoalModes(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&appConfigV7{}); err != nil {
+		return err
+	}
+	return tx.Model(&appConfigV7{}).
+		Where("goal_mode IS NULL OR goal_mode = ''").
+		Updates(map[string]any{"goal_mode": "days", "goal_period": "month"}).Error
+}
+
+// migrateGoalHistory creates the goal history, seeded with the current goal
+// taking effect on the first recorded day so existing months keep their goal
+func migrateGoalHistory(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&goalChangeV8{}); err != nil {
+		return err
+	}
+
+	var count int64
+	tx.Model(&goalChangeV8{}).Count(&count)
+	if count > 0 {
+		return nil
+	}
+
+	var configs []appConfigV8
+	if err := tx.Order("id").Limit(1).Find(&configs).Error; err != nil || len(configs) == 0 {
+		return err
+	}
+
+	since := time.Now()
+	var first presenceRecordV2
+	if err := tx.Order("taken_at").Limit(1).Find(&first).Error; err != nil {
+		return err
+	}
+	if first.ID != 0 && first.TakenAt.Before(since) {
+		since = first.TakenAt.In(loadLocation(first.TimeZone))
+	}
+
+	change := &goalChangeV8{
+		Since:       since.Format(LayoutISO),
+		DefaultGoal: configs[0].DefaultGoal,
+		GoalMode:    cmp.Or(configs[0].GoalMode, "days"),
+		GoalPeriod:  cmp.Or(configs[0].GoalPeriod, "month"),
+	}
+	return tx.Create(change).Error
+}
+
+// migrateCalendarView adds the calendar view setting, off on existing configs
+func migrateCalendarView(tx *gorm.DB) error {
+	return tx.AutoMigrate(&appConfigV9{})
+}
+
+// migrateReportTemplates creates the report templates, seeded with an
+// example that reproduces the built-in report
+func migrateReportTemplates(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&reportTemplateV10{}, &appConfigV10{}); err != nil {
+		return err
+	}
+
+	var count int64
+	tx.Model(&reportTemplateV10{}).Count(&count)
+	if count > 0 {
+		return nil
+	}
+	return tx.Create(&reportTemplateV10{Name: "Padrão", Body: defaultTemplateV10}).Error
+}
+
+// migrateBackupInterval adds the automatic snapshot interval, zero selecting
+// the default on existing configs
+func migrateBackupInterval(tx *gorm.DB) error {
+	return tx.AutoMigrate(&appConfigV11{})
+}
+
+// migrateCalendarRules creates the table of calendar import rules
+func migrateCalendarRules(tx *gorm.DB) error {
+	return tx.AutoMigrate(&calendarRuleV12{})
+}
+
+// defaultTemplateV10 is the example template seeded by migrateReportTemplates
+const defaultTemplateV10 = `Você registrou {{.Goal.Done}} dia(s) presencial(is) {{.Goal.Period}}:
+
+{{range .Records}}{{.Icon}} {{.Date}} - {{if .Area}}{{.Area}} ({{.Response}}){{else}}{{.Response}}{{end}}
+{{end}}{{range seq .Goal.Remaining}}🔲 (presencial pendente)
+{{end}}{{if .Holidays}}
+Feriados do mês:
+{{range .Holidays}}🎉 {{.Date}} - {{.Name}}
+{{end}}{{end}}`
+
+// defaultTemplateV13 is the example template installed by migrateDefaultTemplate
+const defaultTemplateV13 = `Você registrou {{if .Goal.Monthly}}{{.Goal.Done}} dia(s) presencial(is){{else}}{{.Goal.MonthDone}} dia(s) presencial(is) em {{.MonthTitle}} e {{.Goal.Done}}{{end}} {{.Goal.Period}}{{.Goal.Note}}:
+
+{{range .Records}}{{.Icon}} {{.Date}} - {{if .Area}}{{.Area}} ({{.Response}}){{else}}{{.Response}}{{end}}
+{{end}}{{range seq .Goal.Remaining}}🔲 (presencial pendente)
+{{end}}{{with .Forecast}}
+Previsão: faltam {{.Needed}} dia(s) presencial(is) e restam {{.Available}} dia(s) útil(eis) livre(s)
+{{if not .Reachable}}⚠️ Meta inalcançável: não há dias úteis suficientes até o fim do período ({{.PeriodEnd}})
+{{else if eq .Needed .Available}}⚠️ Todos os dias úteis restantes precisam ser presenciais, até {{.LastDay}}
+{{else if gt .Weeks 1}}💡 Ritmo sugerido: {{.PerWeek}} dia(s) por semana até {{.LastDay}}
+{{else}}💡 Ritmo sugerido: {{.Needed}} dia(s) até {{.LastDay}}
+{{end}}{{end}}{{if .Holidays}}
+Feriados do mês:
+{{range .Holidays}}🎉 {{.Date}} - {{.Name}}
+{{end}}{{end}}`
+
+// migrateDefaultTemplate brings the seeded example template up to the one
+// with the goal forecast, unless the user has edited it
+func migrateDefaultTemplate(tx *gorm.DB) error {
+	return tx.Model(&reportTemplateV10{}).
+		Where("body = ?", defaultTemplateV10).
+		Update("body", defaultTemplateV13).Error
+}
+
+// migrateRecordRevisions adds the update time and revision of the records.
+// Existing records count as last updated when they were taken
+func migrateRecordRevisions(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&presenceRecordV14{}); err != nil {
+		return err
+	}
+	return tx.Exec("UPDATE presence_records SET updated_at = taken_at WHERE updated_at IS NULL").Error
+}
+
+// migrateDropRecordMerges removes the log of merged duplicates left by
+// development builds that collapsed them instead of splitting the day
+func migrateDropRecordMerges(tx *gorm.DB) error {
+	return tx.Migrator().DropTable("record_merges")
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// createLegacyDatabase writes a database as the first released version did,
+// before the schema was versioned
+func createLegacyDatabase(t *testing.T, path string, records []presenceRecordV1) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	defer func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	}()
+
+	if err := db.AutoMigrate(&appLanguageV1{}, &appInteractionV1{}, &appV1{}, &appConfigV1{}, &presenceRecordV1{}); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+
+	app := &appV1{
+		AppID:       uuid.New(),
+		Name:        "PresencialApp",
+		Theme:       "light",
+		Language:    appLanguageV1{Title: "Controle de Presença"},
+		Interaction: appInteractionV1{AreaOptions: `{"areas": ["CT", "AG"]}`},
+		AppConfig:   appConfigV1{DefaultGoal: 6},
+	}
+	if err := db.Create(app).Error; err != nil {
+		t.Fatalf("Create app: %v", err)
+	}
+	if len(records) > 0 {
+		if err := db.Create(&records).Error; err != nil {
+			t.Fatalf("Create records: %v", err)
+		}
+	}
+}
+
+func TestMigrateLegacyDatabase(t *testing.T) {
+	useSaoPaulo(t)
+
+	tests := []struct {
+		name    string
+		records []presenceRecordV1
+		// want lists the records as "day slot time response" in date order
+		want []string
+	}{
+		{
+			name: "without records",
+			want: []string{},
+		},
+		{
+			name: "one record per day",
+			records: []presenceRecordV1{
+				{Date: "2024-05-02", Time: "09:15:00", Response: "Presencial", Area: "CT"},
+				{Date: "2024-05-03", Response: "Remoto"},
+			},
+			want: []string{"2024-05-02 0 09:15:00 Presencial", "2024-05-03 0 00:00:00 Remoto"},
+		},
+		{
+			name: "late evening record stays on its local day",
+			records: []presenceRecordV1{
+				{Date: "2024-05-31", Time: "23:30:00", Response: "Presencial", Area: "CT"},
+			},
+			want: []string{"2024-05-31 0 23:30:00 Presencial"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "presencial.db")
+			createLegacyDatabase(t, path, tt.records)
+
+			s, err := NewSQLite(path)
+			if err != nil {
+				t.Fatalf("NewSQLite: %v", err)
+			}
+			defer s.Close()
+
+			var version int
+			s.db.Model(&SchemaVersion{}).Select("COALESCE(MAX(version), 0)").Scan(&version)
+			if version != latestSchemaVersion() {
+				t.Errorf("schema version = %d, want %d", version, latestSchemaVersion())
+			}
+
+			backups, err := filepath.Glob(path + ".v0-*.bak")
+			if err != nil || len(backups) != 1 {
+				t.Fatalf("backups = %v (%v), want one", backups, err)
+			}
+
+			// the backup keeps the legacy layout and data
+			bak, err := gorm.Open(sqlite.Open(backups[0]), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("gorm.Open backup: %v", err)
+			}
+			var legacy int64
+			bak.Model(&presenceRecordV1{}).Count(&legacy)
+			hasDate := bak.Migrator().HasColumn(&presenceRecordV1{}, "date")
+			if sqlDB, err := bak.DB(); err == nil {
+				_ = sqlDB.Close()
+			}
+			if !hasDate || legacy != int64(len(tt.records)) {
+				t.Errorf("backup has date column %v and %d records, want true and %d", hasDate, legacy, len(tt.records))
+			}
+
+			records, err := s.Records()
+			if err != nil {
+				t.Fatalf("Records: %v", err)
+			}
+
+			got := []string{}
+			for i := len(records) - 1; i >= 0; i-- {
+				r := records[i]
+				got = append(got, fmt.Sprintf("%s %d %s %s", r.Date(), r.Slot, r.Time(), r.Response))
+
+				if r.TimeZone != "America/Sao_Paulo" {
+					t.Errorf("record %d time zone = %q", r.ID, r.TimeZone)
+				}
+				if !r.UpdatedAt.Equal(r.TakenAt) || r.Revision != 0 {
+					t.Errorf("record %d updated at %v revision %d, want %v and 0", r.ID, r.UpdatedAt, r.Revision, r.TakenAt)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+
+			app, err := s.LoadApp()
+			if err != nil {
+				t.Fatalf("LoadApp: %v", err)
+			}
+			if app.AppConfig.DefaultGoal != 6 || app.AppConfig.GoalMode != GoalDays || app.AppConfig.GoalPeriod != PeriodMonth {
+				t.Errorf("config = %+v, want the legacy goal of 6 days per month", app.AppConfig)
+			}
+
+			// the seeded example template is brought up to the current one
+			templates, err := s.ReportTemplates()
+			if err != nil {
+				t.Fatalf("ReportTemplates: %v", err)
+			}
+			if len(templates) != 1 || templates[0].Body != DefaultTemplate {
+				t.Errorf("templates = %+v, want the default template", templates)
+			}
+		})
+	}
+}
+
+func TestMigrateNewDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presencial.db")
+
+	s, err := NewSQLite(path)
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+
+	if backups, _ := filepath.Glob(path + ".v*.bak"); len(backups) != 0 {
+		t.Errorf("backups = %v, want none for a new database", backups)
+	}
+
+	// a database touched by a newer build is refused
+	if err := s.db.Create(&SchemaVersion{Version: latestSchemaVersion() + 1}).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	_ = s.Close()
+
+	if _, err := NewSQLite(path); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("NewSQLite error = %v, want %v", err, ErrSchemaTooNew)
+	}
+}
+
+func TestMigrateSplitsDuplicateDays(t *testing.T) {
+	useSaoPaulo(t)
+
+	tests := []struct {
+		name    string
+		records []presenceRecordV1
+		// want lists the records as "day slot time response" in date order
+		want      []string
+		wantSplit []string
+	}{
+		{
+			name: "one record per day",
+			records: []presenceRecordV1{
+				{Date: "2024-05-02", Time: "09:15:00", Response: "Presencial", Area: "CT"},
+				{Date: "2024-05-03", Response: "Remoto"},
+			},
+			want: []string{"2024-05-02 0 09:15:00 Presencial", "2024-05-03 0 00:00:00 Remoto"},
+		},
+		{
+			name: "duplicates become a split day in the order they were taken",
+			records: []presenceRecordV1{
+				{Date: "2024-05-02", Time: "14:00:00", Response: "Remoto"},
+				{Date: "2024-05-02", Time: "09:00:00", Response: "Presencial", Area: "CT"},
+				{Date: "2024-05-03", Time: "10:00:00", Response: "Presencial", Area: "CT"},
+				{Date: "2024-05-02", Time: "18:00:00", Response: "Presencial", Area: "AG"},
+			},
+			want: []string{
+				"2024-05-02 0 09:00:00 Presencial",
+				"2024-05-02 1 14:00:00 Remoto",
+				"2024-05-02 2 18:00:00 Presencial",
+				"2024-05-03 0 10:00:00 Presencial",
+			},
+			wantSplit: []string{"2024-05-02"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "presencial.db")
+			createLegacyDatabase(t, path, tt.records)
+
+			s, err := NewSQLite(path)
+			if err != nil {
+				t.Fatalf("NewSQLite: %v", err)
+			}
+
+			records, err := s.Records()
+			if err != nil {
+				t.Fatalf("Records: %v", err)
+			}
+
+			got := []string{}
+			for i := len(records) - 1; i >= 0; i-- {
+				r := records[i]
+				got = append(got, fmt.Sprintf("%s %d %s %s", r.Date(), r.Slot, r.Time(), r.Response))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+			if split := s.SplitDays(); !slices.Equal(split, tt.wantSplit) {
+				t.Errorf("split days = %v, want %v", split, tt.wantSplit)
+			}
+			_ = s.Close()
+
+			// the split days are reported only by the open that migrated them
+			s, err = NewSQLite(path)
+			if err != nil {
+				t.Fatalf("NewSQLite: %v", err)
+			}
+			defer s.Close()
+			if split := s.SplitDays(); len(split) != 0 {
+				t.Errorf("split days after reopening = %v, want none", split)
+			}
+		})
+	}
+}
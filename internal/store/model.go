@@ -0,0 +1,182 @@
+package store
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// App represents the main application entity with configuration and localization settings
+type App struct {
+	ID            uint `gorm:"primarykey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	AppID         uuid.UUID
+	Name          string
+	Theme         string
+	LanguageID    uint
+	Language      AppLanguage `gorm:"foreignKey:LanguageID"`
+	InteractionID uint
+	Interaction   AppInteraction `gorm:"foreignKey:InteractionID"`
+	AppConfigID   uint
+	AppConfig     AppConfig `gorm:"foreignKey:AppConfigID"`
+}
+
+// AppLanguage contains all the localized text strings used in the application interface
+type AppLanguage struct {
+	ID          uint `gorm:"primarykey"`
+	CreatedAt   time.Time
+	WindowName  string
+	Title       string
+	Welcome     string
+	Goal        string
+	Report      string
+	Observation string
+	Area        string
+	Save        string
+	Cancel      string
+	Yes         string
+	No          string
+	Close       string
+	Error       string
+	Success     string
+	SuccessMsg  string
+	ErrorMsg    string
+	WarningMsg  string
+	Warning     string
+	Info        string
+}
+
+// AppInteraction defines the interaction elements and options for the application
+type AppInteraction struct {
+	ID          uint `gorm:"primarykey"`
+	ExtraLabel  string
+	AreaOptions string
+	Headers     string
+}
+
+// AppConfig stores configuration settings for the application
+type AppConfig struct {
+	ID          uint `gorm:"primarykey"`
+	DefaultGoal int
+	GoalMode    GoalMode   `gorm:"default:days"`
+	GoalPeriod  GoalPeriod `gorm:"default:month"`
+	// CalendarView shows the month as a calendar grid in the main window
+	CalendarView bool
+	// MainTemplateID selects the report template of the main window, zero for the built-in report
+	MainTemplateID uint
+	// BackupInterval is the number of hours between automatic snapshots, zero
+	// for the default and negative to take them only on startup
+	BackupInterval int
+}
+
+// GoalChange keeps the goal in force from a day on, so past periods are
+// judged against the goal that applied at the time
+type GoalChange struct {
+	ID          uint   `gorm:"primarykey"`
+	Since       string `gorm:"uniqueIndex"`
+	DefaultGoal int
+	GoalMode    GoalMode
+	GoalPeriod  GoalPeriod
+}
+
+// Config returns the goal settings held by the change
+func (g *GoalChange) Config() AppConfig {
+	return AppConfig{DefaultGoal: g.DefaultGoal, GoalMode: g.GoalMode, GoalPeriod: g.GoalPeriod}
+}
+
+// ReportTemplate is a user-defined report written in text/template syntax
+// and rendered with a ReportData
+type ReportTemplate struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"uniqueIndex"`
+	Body string
+}
+
+// PresenceRecord to hold records. A profile has a single record per day,
+// unless the day is split, in which case each part takes its own slot
+type PresenceRecord struct {
+	ID          uint      `gorm:"primaryKey"`
+	ProfileID   uint      `gorm:"uniqueIndex:idx_record_day"`
+	Day         string    `gorm:"uniqueIndex:idx_record_day"`
+	Slot        int       `gorm:"uniqueIndex:idx_record_day"`
+	TakenAt     time.Time `gorm:"index"`
+	TimeZone    string
+	Response    string
+	Observation string
+	Area        string
+	// Retroactive marks records entered on a different day than the one they refer to
+	Retroactive bool
+	// UpdatedAt and Revision change whenever the record is edited or replaced,
+	// so exported calendar events can announce a newer version
+	UpdatedAt time.Time
+	Revision  int
+}
+
+// BeforeSave normalizes the timestamp to UTC so records sort and compare consistently
+func (r *PresenceRecord) BeforeSave(*gorm.DB) error {
+	if r.TimeZone == "" {
+		r.TimeZone = LocalTimeZone()
+	}
+	r.TakenAt = r.TakenAt.UTC()
+	r.Day = r.Date()
+	return nil
+}
+
+// Local returns the moment the record was taken in the time zone it was taken in
+func (r *PresenceRecord) Local() time.Time {
+	return r.TakenAt.In(loadLocation(r.TimeZone))
+}
+
+// Date returns the record day formatted with LayoutISO
+func (r *PresenceRecord) Date() string {
+	return r.Local().Format(LayoutISO)
+}
+
+// Time returns the record time of day formatted as HH:MM:SS
+func (r *PresenceRecord) Time() string {
+	return r.Local().Format(layoutTime)
+}
+
+// ResponseType describes a kind of day the user can record, such as office,
+// remote or vacation. Records reference it by name in PresenceRecord.Response
+type ResponseType struct {
+	ID               uint   `gorm:"primarykey"`
+	Name             string `gorm:"uniqueIndex"`
+	Icon             string
+	CountsTowardGoal bool
+	RequiresArea     bool
+	Position         int
+}
+
+// Holiday is a non-working day added by the user or imported from a
+// calendar file. National holidays are computed and never stored
+type Holiday struct {
+	ID     uint   `gorm:"primarykey"`
+	Day    string `gorm:"index"`
+	Name   string
+	Source string
+}
+
+// CalendarRule recognizes records in the events of an imported calendar: an
+// event whose summary or location matches Pattern gets Value as its response
+// or area, depending on Target. Pattern is a keyword, matched ignoring case
+// and accents, or a regular expression when Regex is set
+type CalendarRule struct {
+	ID       uint `gorm:"primarykey"`
+	Target   string
+	Value    string
+	Pattern  string
+	Regex    bool
+	Position int
+}
+
+// Date returns the holiday day at midnight in the local time zone
+func (h *Holiday) Date() time.Time {
+	t, err := time.ParseInLocation(LayoutISO, h.Day, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,229 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/presencial/internal/pdf"
+)
+
+const (
+	pdfMargin  = 40.0
+	pdfRowHigh = 15.0
+	pdfFont    = 9.0
+	// pdfBottom is the lowest y content may reach, above the footer
+	pdfBottom = pdf.PageHeight - pdfMargin - 16
+	// pdfTotalsHigh is the height of the totals below the day table
+	pdfTotalsHigh = 84.0
+)
+
+// pdfColumns are the titles and widths of the day-by-day table
+var pdfColumns = []struct {
+	title string
+	width float64
+}{
+	{"Data", 62},
+	{"Dia", 30},
+	{"Resposta", 110},
+	{"Área", 60},
+	{"Observação", 253.28},
+}
+
+// ExportMonthPDF writes a report of the month of month, listing every day
+// with its record, the goal in force and the totals, for the profile named
+// profile. Months with many split days continue on a second page
+func ExportMonthPDF(repo Repository, filePath, profile string, month time.Time) error {
+	app, err := repo.LoadApp()
+	if err != nil {
+		return err
+	}
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, 0)
+
+	records, err := repo.RecordsBetween(from, to)
+	if err != nil {
+		return err
+	}
+
+	hs, err := repo.Holidays(from, to)
+	if err != nil {
+		return err
+	}
+
+	types, err := repo.ResponseTypes()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := repo.GoalAt(to.AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+
+	target, err := TargetBetween(repo, from, to)
+	if err != nil {
+		return err
+	}
+
+	doc := pdf.New()
+	doc.AddPage()
+
+	title := app.Language.Report
+	if title == "" {
+		title = "Relatório de Presença"
+	}
+
+	y := pdfMargin + 18
+	doc.Text(pdfMargin, y, 18, true, title)
+	y += 26
+	for _, line := range [][2]string{
+		{"Nome:", profile},
+		{"Mês:", MonthTitle(from)},
+		{"Meta:", fmt.Sprintf("%s (%d dia(s) presencial(is) no mês)", cfg.Describe(), target)},
+	} {
+		doc.Text(pdfMargin, y, 11, true, line[0])
+		doc.Text(pdfMargin+45, y, 11, false, line[1])
+		y += 16
+	}
+
+	footer := "Gerado em " + time.Now().Format(layoutBR+" 15:04")
+	y = drawDayTable(doc, y+8, footer, from, to, records, hs)
+
+	done := CountGoalDays(records, types)
+	status := "não atingida"
+	if done >= target {
+		status = "atingida"
+	}
+
+	if y+pdfTotalsHigh > pdfBottom {
+		y = nextPDFPage(doc, footer)
+	}
+	y += 24
+	doc.Text(pdfMargin, y, 12, true, "Totais")
+	y += 18
+	doc.Text(pdfMargin, y, pdfFont+1, false,
+		fmt.Sprintf("Dias presenciais: %d de %d (meta %s)", done, target, status))
+	y += 14
+
+	var byResponse, byArea []string
+	counts := map[string]int{}
+	areas := map[string]int{}
+	var areaOrder []string
+	for _, r := range records {
+		counts[r.Response]++
+		if r.Area != "" {
+			if areas[r.Area] == 0 {
+				areaOrder = append(areaOrder, r.Area)
+			}
+			areas[r.Area]++
+		}
+	}
+	for _, t := range types {
+		if counts[t.Name] > 0 {
+			byResponse = append(byResponse, fmt.Sprintf("%s: %d", t.Name, counts[t.Name]))
+		}
+	}
+	for _, a := range areaOrder {
+		byArea = append(byArea, fmt.Sprintf("%s: %d", a, areas[a]))
+	}
+
+	if len(byResponse) > 0 {
+		doc.Text(pdfMargin, y, pdfFont+1, false, "Por resposta: "+strings.Join(byResponse, ", "))
+		y += 14
+	}
+	if len(byArea) > 0 {
+		doc.Text(pdfMargin, y, pdfFont+1, false, "Por área: "+strings.Join(byArea, ", "))
+	}
+
+	doc.Text(pdfMargin, pdf.PageHeight-pdfMargin, 8, false, footer)
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := doc.WriteTo(f); err != nil {
+		return fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+	return f.Close()
+}
+
+// nextPDFPage writes the footer on the current page and starts a new one,
+// returning the y its content starts at
+func nextPDFPage(doc *pdf.Document, footer string) float64 {
+	doc.Text(pdfMargin, pdf.PageHeight-pdfMargin, 8, false, footer)
+	doc.AddPage()
+	return pdfMargin
+}
+
+// drawDayTable draws one row per day of [from, to), or one per record on
+// split days, starting at y. Rows that would reach the footer continue on a
+// new page under the column titles. It returns the y below the table
+func drawDayTable(doc *pdf.Document, y float64, footer string, from, to time.Time, records []PresenceRecord, hs []Holiday) float64 {
+	byDay := map[string][]PresenceRecord{}
+	for _, r := range records {
+		byDay[r.Date()] = append(byDay[r.Date()], r)
+	}
+
+	holidayNames := map[string]string{}
+	for _, h := range hs {
+		holidayNames[h.Day] = h.Name
+	}
+
+	tableWidth := pdf.PageWidth - 2*pdfMargin
+	titles := make([]string, len(pdfColumns))
+	for i, c := range pdfColumns {
+		titles[i] = c.title
+	}
+
+	// row draws a row over a background of the given gray level, none when 0
+	var row func(cells []string, bold bool, gray float64)
+	row = func(cells []string, bold bool, gray float64) {
+		if !bold && y+pdfRowHigh > pdfBottom {
+			y = nextPDFPage(doc, footer)
+			row(titles, true, 0.85)
+		}
+		if gray > 0 {
+			doc.FillRect(pdfMargin, y, tableWidth, pdfRowHigh, gray)
+		}
+
+		x := pdfMargin
+		for i, c := range pdfColumns {
+			doc.Text(x+3, y+pdfRowHigh-4, pdfFont, bold, pdf.Fit(cells[i], pdfFont, c.width-6, bold))
+			x += c.width
+		}
+		y += pdfRowHigh
+		doc.Line(pdfMargin, y, pdfMargin+tableWidth, y)
+	}
+
+	row(titles, true, 0.85)
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(LayoutISO)
+		date, weekday := day.Format(layoutBR), WeekdayName(day)
+
+		dayRecords := byDay[key]
+		// Records are newest first, the table reads top to bottom
+		for i := len(dayRecords) - 1; i >= 0; i-- {
+			r := dayRecords[i]
+			row([]string{date, weekday, r.Response, r.Area, r.Observation}, false, 0)
+		}
+		if len(dayRecords) > 0 {
+			continue
+		}
+
+		switch name, ok := holidayNames[key]; {
+		case ok:
+			row([]string{date, weekday, "Feriado", "", name}, false, 0.95)
+		case IsWeekend(day):
+			row([]string{date, weekday, "", "", ""}, false, 0.95)
+		default:
+			row([]string{date, weekday, "—", "", ""}, false, 0)
+		}
+	}
+	return y
+}
@@ -0,0 +1,289 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultTemplate reproduces the built-in report of the main window,
+// including the goal forecast
+const DefaultTemplate = `Você registrou {{if .Goal.Monthly}}{{.Goal.Done}} dia(s) presencial(is){{else}}{{.Goal.MonthDone}} dia(s) presencial(is) em {{.MonthTitle}} e {{.Goal.Done}}{{end}} {{.Goal.Period}}{{.Goal.Note}}:
+
+{{range .Records}}{{.Icon}} {{.Date}} - {{if .Area}}{{.Area}} ({{.Response}}){{else}}{{.Response}}{{end}}
+{{end}}{{range seq .Goal.Remaining}}🔲 (presencial pendente)
+{{end}}{{with .Forecast}}
+Previsão: faltam {{.Needed}} dia(s) presencial(is) e restam {{.Available}} dia(s) útil(eis) livre(s)
+{{if not .Reachable}}⚠️ Meta inalcançável: não há dias úteis suficientes até o fim do período ({{.PeriodEnd}})
+{{else if eq .Needed .Available}}⚠️ Todos os dias úteis restantes precisam ser presenciais, até {{.LastDay}}
+{{else if gt .Weeks 1}}💡 Ritmo sugerido: {{.PerWeek}} dia(s) por semana até {{.LastDay}}
+{{else}}💡 Ritmo sugerido: {{.Needed}} dia(s) até {{.LastDay}}
+{{end}}{{end}}{{if .Holidays}}
+Feriados do mês:
+{{range .Holidays}}🎉 {{.Date}} - {{.Name}}
+{{end}}{{end}}`
+
+// ReportData is the aggregation of a month behind the reports, the
+// templates and the copied summaries
+type ReportData struct {
+	Month      time.Time
+	MonthTitle string
+	Records    []ReportRecord
+	Holidays   []ReportHoliday
+	Goal       ReportGoal
+	// Forecast is nil once the goal is reached or its period is over
+	Forecast   *ReportForecast
+	ByArea     []Count
+	ByResponse []Count
+
+	// Config and Status are the goal in force and its evaluation
+	Config *AppConfig
+	Status GoalStatus
+}
+
+// ReportRecord is a presence record formatted for reports
+type ReportRecord struct {
+	Date             string
+	Weekday          string
+	Time             string
+	Response         string
+	Icon             string
+	Area             string
+	Observation      string
+	CountsTowardGoal bool
+	Retroactive      bool
+	TakenAt          time.Time
+}
+
+// ReportHoliday is a holiday formatted for reports
+type ReportHoliday struct {
+	Date string
+	Name string
+}
+
+// ReportGoal is the progress toward the goal of the period the report covers.
+// MonthDone counts the office days of the month alone, which differs from
+// Done when the goal period is not the month
+type ReportGoal struct {
+	Description string
+	Period      string
+	Monthly     bool
+	Target      int
+	Done        int
+	MonthDone   int
+	Remaining   int
+	Reached     bool
+	// Note explains the goal when it is not a plain number of days per month,
+	// e.g. " (meta ajustada pelos feriados: 9)"
+	Note string
+}
+
+// ReportForecast is the goal forecast formatted for reports
+type ReportForecast struct {
+	Needed    int
+	Available int
+	Weeks     int
+	PerWeek   int
+	Reachable bool
+	LastDay   string
+	PeriodEnd string
+}
+
+// Count is a named total
+type Count struct {
+	Name  string
+	Count int
+}
+
+// BuildReport aggregates the records, holidays and goal of the month of
+// month. The goal is the one in force at the end of past months, or today
+// for the current month
+func BuildReport(repo Repository, month, now time.Time) (*ReportData, error) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, 0)
+
+	records, err := repo.RecordsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	hs, err := repo.Holidays(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := repo.ResponseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	ref := now
+	if ref.Before(from) || !ref.Before(to) {
+		ref = to.AddDate(0, 0, -1)
+	}
+
+	cfg, err := repo.GoalAt(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := EvaluateGoal(repo, cfg, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &ReportData{
+		Month:      from,
+		MonthTitle: MonthTitle(from),
+		Config:     cfg,
+		Status:     status,
+		Goal: ReportGoal{
+			Description: cfg.Describe(),
+			Period:      cfg.Period().Text(status.From),
+			Monthly:     cfg.Period() == PeriodMonth,
+			Target:      status.Target,
+			Done:        status.Done,
+			MonthDone:   CountGoalDays(records, types),
+			Remaining:   status.Remaining(),
+			Reached:     status.Reached(),
+			Note:        goalNote(cfg, status),
+		},
+	}
+
+	if !status.Reached() && status.To.After(StartOfDay(now)) {
+		f, err := ForecastGoal(repo, status, now)
+		if err != nil {
+			return nil, err
+		}
+		data.Forecast = &ReportForecast{
+			Needed:    f.Needed,
+			Available: len(f.Available),
+			Weeks:     f.Weeks(),
+			PerWeek:   f.PerWeek(),
+			Reachable: f.Reachable(),
+			LastDay:   f.LastDay().Format(layoutBR),
+			PeriodEnd: status.To.AddDate(0, 0, -1).Format(layoutBR),
+		}
+	}
+
+	catalog := map[string]ResponseType{}
+	for _, t := range types {
+		catalog[t.Name] = t
+	}
+
+	areas := map[string]int{}
+	responses := map[string]int{}
+	for _, r := range records {
+		rt, ok := catalog[r.Response]
+		icon := rt.Icon
+		if !ok {
+			icon = "☑️"
+		}
+
+		data.Records = append(data.Records, ReportRecord{
+			Date:             r.Local().Format(layoutBR),
+			Weekday:          WeekdayName(r.Local()),
+			Time:             r.Time(),
+			Response:         r.Response,
+			Icon:             icon,
+			Area:             r.Area,
+			Observation:      r.Observation,
+			CountsTowardGoal: rt.CountsTowardGoal,
+			Retroactive:      r.Retroactive,
+			TakenAt:          r.Local(),
+		})
+
+		responses[r.Response]++
+		if r.Area != "" {
+			areas[r.Area]++
+		}
+	}
+
+	for _, h := range hs {
+		data.Holidays = append(data.Holidays, ReportHoliday{Date: h.Date().Format(layoutBR), Name: h.Name})
+	}
+
+	configured, err := repo.Areas()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range appendMissing(configured, areas) {
+		if areas[a] > 0 {
+			data.ByArea = append(data.ByArea, Count{Name: a, Count: areas[a]})
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	for _, name := range appendMissing(names, responses) {
+		if responses[name] > 0 {
+			data.ByResponse = append(data.ByResponse, Count{Name: name, Count: responses[name]})
+		}
+	}
+
+	return data, nil
+}
+
+// goalNote explains a goal in percent, of another period or adjusted by holidays
+func goalNote(cfg *AppConfig, status GoalStatus) string {
+	switch {
+	case cfg.Mode() == GoalPercent:
+		return fmt.Sprintf(" (meta: %s = %d dia(s))", cfg.Describe(), status.Target)
+	case cfg.Period() != PeriodMonth:
+		return fmt.Sprintf(" (meta: %s)", cfg.Describe())
+	case status.Target != cfg.DefaultGoal:
+		return fmt.Sprintf(" (meta ajustada pelos feriados: %d)", status.Target)
+	default:
+		return ""
+	}
+}
+
+// templateFuncs are the helpers available to report templates
+var templateFuncs = template.FuncMap{
+	"seq":   func(n int) []int { return make([]int, max(n, 0)) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+	"pad": func(width int, s string) string {
+		if n := width - len([]rune(s)); n > 0 {
+			return s + strings.Repeat(" ", n)
+		}
+		return s
+	},
+	"reverse": func(records []ReportRecord) []ReportRecord {
+		out := slices.Clone(records)
+		slices.Reverse(out)
+		return out
+	},
+}
+
+// ValidateTemplate trims the name and checks that the body parses
+func ValidateTemplate(tpl *ReportTemplate) error {
+	tpl.Name = strings.TrimSpace(tpl.Name)
+	if tpl.Name == "" {
+		return fmt.Errorf("%w: nome ausente", ErrInvalidTemplate)
+	}
+
+	if _, err := template.New(tpl.Name).Funcs(templateFuncs).Parse(tpl.Body); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return nil
+}
+
+// RenderTemplate executes a report template body with data
+func RenderTemplate(body string, data *ReportData) (string, error) {
+	tpl, err := template.New("relatório").Funcs(templateFuncs).Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	var b strings.Builder
+	if err := tpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("erro ao gerar relatório: %w", err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,158 @@
+package store
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// SummaryFormat selects the markup of a shared month summary
+type SummaryFormat string
+
+// Summary formats
+const (
+	SummaryText     SummaryFormat = "text"
+	SummaryMarkdown SummaryFormat = "markdown"
+	SummaryHTML     SummaryFormat = "html"
+)
+
+// summaryColumns are the titles of the record table of a shared summary
+var summaryColumns = []string{"Data", "Dia", "Resposta", "Área", "Observação"}
+
+// FormatSummary lays out the month of data for pasting into chat or email:
+// the goal progress, one line per record in date order, the totals by area
+// and response and the holidays
+func FormatSummary(data *ReportData, format SummaryFormat) string {
+	title := "Presença - " + data.MonthTitle
+	goal := data.goalLine()
+
+	rows := make([][]string, 0, len(data.Records))
+	// Records are newest first, the summary reads top to bottom
+	for i := len(data.Records) - 1; i >= 0; i-- {
+		r := data.Records[i]
+		rows = append(rows, []string{r.Date, r.Weekday, r.Response, r.Area, r.Observation})
+	}
+
+	totals := [][2]string{
+		{"Por área", joinCounts(data.ByArea)},
+		{"Por resposta", joinCounts(data.ByResponse)},
+	}
+
+	var holidays []string
+	for _, h := range data.Holidays {
+		holidays = append(holidays, h.Date+" - "+h.Name)
+	}
+
+	var b strings.Builder
+	switch format {
+	case SummaryMarkdown:
+		fmt.Fprintf(&b, "**%s**\n\n%s\n\n", markdownCell(title), markdownCell(goal))
+		if len(rows) > 0 {
+			b.WriteString("| " + strings.Join(summaryColumns, " | ") + " |\n")
+			b.WriteString(strings.Repeat("| --- ", len(summaryColumns)) + "|\n")
+			for _, row := range rows {
+				cells := make([]string, len(row))
+				for i, c := range row {
+					cells[i] = markdownCell(c)
+				}
+				b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+			}
+			b.WriteString("\n")
+		}
+		for _, t := range totals {
+			if t[1] != "" {
+				fmt.Fprintf(&b, "**%s:** %s  \n", t[0], markdownCell(t[1]))
+			}
+		}
+		if len(holidays) > 0 {
+			b.WriteString("\n**Feriados:**\n\n")
+			for _, h := range holidays {
+				b.WriteString("- " + markdownCell(h) + "\n")
+			}
+		}
+
+	case SummaryHTML:
+		fmt.Fprintf(&b, "<h3>%s</h3>\n<p>%s</p>\n", html.EscapeString(title), html.EscapeString(goal))
+		if len(rows) > 0 {
+			b.WriteString("<table border=\"1\" cellpadding=\"4\" cellspacing=\"0\">\n<tr>")
+			for _, c := range summaryColumns {
+				b.WriteString("<th>" + html.EscapeString(c) + "</th>")
+			}
+			b.WriteString("</tr>\n")
+			for _, row := range rows {
+				b.WriteString("<tr>")
+				for _, c := range row {
+					b.WriteString("<td>" + html.EscapeString(c) + "</td>")
+				}
+				b.WriteString("</tr>\n")
+			}
+			b.WriteString("</table>\n")
+		}
+		for _, t := range totals {
+			if t[1] != "" {
+				fmt.Fprintf(&b, "<p><b>%s:</b> %s</p>\n", t[0], html.EscapeString(t[1]))
+			}
+		}
+		if len(holidays) > 0 {
+			b.WriteString("<p><b>Feriados:</b></p>\n<ul>\n")
+			for _, h := range holidays {
+				b.WriteString("<li>" + html.EscapeString(h) + "</li>\n")
+			}
+			b.WriteString("</ul>\n")
+		}
+
+	default:
+		fmt.Fprintf(&b, "%s\n%s\n\n", title, goal)
+		for _, row := range rows {
+			line := row[0] + " (" + row[1] + ") - " + row[2]
+			if row[3] != "" {
+				line += " - " + row[3]
+			}
+			if row[4] != "" {
+				line += ": " + row[4]
+			}
+			b.WriteString(line + "\n")
+		}
+		if len(rows) > 0 {
+			b.WriteString("\n")
+		}
+		for _, t := range totals {
+			if t[1] != "" {
+				fmt.Fprintf(&b, "%s: %s\n", t[0], t[1])
+			}
+		}
+		if len(holidays) > 0 {
+			b.WriteString("\nFeriados:\n" + strings.Join(holidays, "\n") + "\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// goalLine describes the progress toward the goal of the period the summary covers
+func (d *ReportData) goalLine() string {
+	g := d.Goal
+	line := fmt.Sprintf("%d de %d dia(s) presencial(is) %s (meta: %s)", g.Done, g.Target, g.Period, g.Description)
+	if !g.Monthly {
+		line = fmt.Sprintf("%d dia(s) presencial(is) em %s; ", g.MonthDone, d.MonthTitle) + line
+	}
+	if g.Reached {
+		return line + " - meta atingida"
+	}
+	return line + fmt.Sprintf(" - faltam %d", g.Remaining)
+}
+
+// joinCounts lists named totals as "name: count" separated by commas
+func joinCounts(counts []Count) string {
+	parts := make([]string, 0, len(counts))
+	for _, c := range counts {
+		parts = append(parts, fmt.Sprintf("%s: %d", c.Name, c.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// markdownCell escapes the characters that break a Markdown table or emphasis
+func markdownCell(s string) string {
+	s = strings.NewReplacer("\\", "\\\\", "|", "\\|", "*", "\\*", "_", "\\_", "\r", "", "\n", " ").Replace(s)
+	return strings.TrimSpace(s)
+}
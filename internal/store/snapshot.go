@@ -0,0 +1,237 @@
+package store
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// SnapshotDir is the folder, next to the database, that holds the automatic snapshots
+	SnapshotDir = "backups"
+
+	// DefaultBackupInterval is the interval of automatic snapshots when none is configured
+	DefaultBackupInterval = 24 * time.Hour
+
+	snapshotPrefix = "presencial-"
+	snapshotExt    = ".db"
+	snapshotLayout = "20060102-150405"
+)
+
+// BackupEvery returns the interval between automatic snapshots, zero when
+// they are only taken on startup
+func (c *AppConfig) BackupEvery() time.Duration {
+	switch {
+	case c.BackupInterval < 0:
+		return 0
+	case c.BackupInterval == 0:
+		return DefaultBackupInterval
+	default:
+		return time.Duration(c.BackupInterval) * time.Hour
+	}
+}
+
+// Retention decides which automatic snapshots are kept: every snapshot of
+// the last Recent period, then the newest of each of the last Daily days,
+// Weekly weeks and Monthly months
+type Retention struct {
+	Recent  time.Duration
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// DefaultRetention keeps a day of snapshots, then a week of daily ones, a
+// month of weekly ones and a year of monthly ones
+var DefaultRetention = Retention{Recent: 24 * time.Hour, Daily: 7, Weekly: 4, Monthly: 12}
+
+// SnapshotInfo describes an automatic snapshot file
+type SnapshotInfo struct {
+	Path    string
+	TakenAt time.Time
+	Size    int64
+}
+
+// TakeSnapshot writes a snapshot of the database to dir, named after now,
+// and returns its path
+func TakeSnapshot(repo Repository, dir string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("erro ao criar pasta de backups: %w", err)
+	}
+
+	path := filepath.Join(dir, snapshotPrefix+now.Format(snapshotLayout)+snapshotExt)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := repo.Snapshot(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ListSnapshots returns the snapshots in dir, newest first
+func ListSnapshots(dir string) ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar backups: %w", err)
+	}
+
+	var snapshots []SnapshotInfo
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || !strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotExt)
+		takenAt, err := time.ParseInLocation(snapshotLayout, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, SnapshotInfo{Path: filepath.Join(dir, name), TakenAt: takenAt, Size: info.Size()})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].TakenAt.After(snapshots[j].TakenAt) })
+	return snapshots, nil
+}
+
+// RotateSnapshots removes the snapshots in dir the retention does not keep
+// and returns how many were removed
+func RotateSnapshots(dir string, now time.Time, retention Retention) (int, error) {
+	snapshots, err := ListSnapshots(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	keep := retention.keeper(now)
+	removed := 0
+	for _, s := range snapshots {
+		if keep(s.TakenAt) {
+			continue
+		}
+		if err := os.Remove(s.Path); err != nil {
+			return removed, fmt.Errorf("erro ao remover backup antigo: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// keeper returns a function that, called with the snapshot times newest
+// first, reports whether the retention keeps each of them
+func (r Retention) keeper(now time.Time) func(time.Time) bool {
+	days := map[string]bool{}
+	weeks := map[string]bool{}
+	months := map[string]bool{}
+
+	// first marks the newest snapshot of a bucket while fewer than limit buckets were seen
+	first := func(seen map[string]bool, key string, limit int) bool {
+		if seen[key] || len(seen) >= limit {
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+
+	return func(t time.Time) bool {
+		year, week := t.ISOWeek()
+		daily := first(days, t.Format(LayoutISO), r.Daily)
+		weekly := first(weeks, fmt.Sprintf("%d-%02d", year, week), r.Weekly)
+		monthly := first(months, t.Format("2006-01"), r.Monthly)
+		return now.Sub(t) < r.Recent || daily || weekly || monthly
+	}
+}
+
+// SnapshotRecords counts the presence records of a snapshot, opening it read-only
+func SnapshotRecords(path string) (int64, error) {
+	dsn := "file:" + filepath.ToSlash(path) + "?mode=ro"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return 0, fmt.Errorf("erro ao abrir backup: %w", err)
+	}
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}()
+
+	var count int64
+	if err := db.Table("presence_records").Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("erro ao ler backup: %w", err)
+	}
+	return count, nil
+}
+
+// RestoreSnapshot replaces the database at dbPath with a snapshot. The
+// snapshot is copied and migrated next to the database first, so a snapshot
+// that cannot be opened leaves the database untouched. The database must be
+// closed while it is replaced
+func RestoreSnapshot(snapshot, dbPath string) error {
+	tmp := dbPath + ".restore"
+	defer cleanupRestore(tmp)
+
+	if err := copyFile(snapshot, tmp); err != nil {
+		return err
+	}
+
+	// Opening the copy brings snapshots of older versions up to date
+	db, err := NewSQLite(tmp)
+	if err != nil {
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, dbPath); err != nil {
+		return fmt.Errorf("erro ao restaurar backup: %w", err)
+	}
+	return nil
+}
+
+// cleanupRestore removes the working copy of a restore and the migration backups made of it
+func cleanupRestore(tmp string) {
+	_ = os.Remove(tmp)
+	if leftovers, err := filepath.Glob(tmp + ".v*.bak"); err == nil {
+		for _, f := range leftovers {
+			_ = os.Remove(f)
+		}
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("erro ao ler backup: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("erro ao copiar backup: %w", err)
+	}
+	return out.Close()
+}
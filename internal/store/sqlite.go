@@ -0,0 +1,842 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/dyammarcano/presencial/internal/holidays"
+)
+
+// SQLite is the Repository implementation backed by a SQLite database file
+type SQLite struct {
+	db        *gorm.DB
+	splitDays []string
+}
+
+// NewSQLite opens the database at path and runs the pending schema migrations
+func NewSQLite(path string) (*SQLite, error) {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar no banco de dados: %v", err)
+	}
+
+	from, err := migrate(db, path)
+	if err != nil {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	s := &SQLite{db: db}
+	if from < versionOneRecordPerDay {
+		// records only got slots in that step, so every split day comes from it
+		if err := db.Model(&PresenceRecord{}).Distinct("day").Where("slot > 0").Order("day").
+			Pluck("day", &s.splitDays).Error; err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("erro ao verificar dias divididos: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// LoadApp returns the application entity with its language, interaction and config
+func (s *SQLite) LoadApp() (*App, error) {
+	var app App
+	if err := s.db.
+		Preload("Language").
+		Preload("Interaction").
+		Preload("AppConfig").
+		First(&app).Error; err != nil {
+		return nil, fmt.Errorf("erro ao carregar dados do app: %w", err)
+	}
+	return &app, nil
+}
+
+// CreateDefaultApp seeds the database with the default application data
+func (s *SQLite) CreateDefaultApp() (*App, error) {
+	var count int64
+	s.db.Model(&App{}).Count(&count)
+	if count > 0 {
+		return s.LoadApp()
+	}
+
+	app := &App{
+		AppID: uuid.New(),
+		Name:  "PresencialApp",
+		Theme: "light",
+		Language: AppLanguage{
+			WindowName:  "Controle de Presença",
+			Title:       "Controle de Presença",
+			Welcome:     "Bem-vindo",
+			Goal:        "Meta de dias presenciais",
+			Report:      "Relatório de Presença",
+			Observation: "Observação",
+			Area:        "Área",
+			Save:        "Salvar",
+			Cancel:      "Cancelar",
+			Yes:         "Sim",
+			No:          "Não",
+			Close:       "Fechar",
+			Error:       "Erro",
+			Success:     "Sucesso",
+			SuccessMsg:  "Presença registrada com sucesso",
+			ErrorMsg:    "Erro ao registrar presença",
+			Warning:     "Aviso",
+			WarningMsg:  "Meta já atingida",
+			Info:        "Informação",
+		},
+		Interaction: AppInteraction{
+			ExtraLabel:  "adicional",
+			AreaOptions: `{"areas": ["CT", "CEIC", "AG", "OUTRO"]}`,
+			Headers:     `{"headers": ["data", "hora", "resposta", "observacao", "area"]}`,
+		},
+		AppConfig: AppConfig{
+			DefaultGoal: 4,
+			GoalMode:    GoalDays,
+			GoalPeriod:  PeriodMonth,
+		},
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(app).Error; err != nil {
+			return err
+		}
+		return tx.Create(goalChange(&app.AppConfig, time.Now())).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar dados padrão: %w", err)
+	}
+	return app, nil
+}
+
+// Records returns every presence record, newest first
+func (s *SQLite) Records() ([]PresenceRecord, error) {
+	var records []PresenceRecord
+	if err := s.db.Order("taken_at DESC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("erro ao carregar registros: %w", err)
+	}
+	return records, nil
+}
+
+// RecordsBetween returns the records taken in [from, to), newest first
+func (s *SQLite) RecordsBetween(from, to time.Time) ([]PresenceRecord, error) {
+	var records []PresenceRecord
+	if err := s.db.
+		Where("taken_at >= ? AND taken_at < ?", from.UTC(), to.UTC()).
+		Order("taken_at DESC").
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("erro ao carregar registros: %w", err)
+	}
+	return records, nil
+}
+
+// SaveRecord stores a new presence record. Records without a timestamp are
+// stamped with the current time, records for another day are flagged
+// retroactive. The policy decides what happens when the day already has a record
+func (s *SQLite) SaveRecord(record *PresenceRecord, policy ConflictPolicy) error {
+	now := time.Now()
+	if record.TakenAt.IsZero() {
+		record.TakenAt = now
+	}
+	record.TimeZone = LocalTimeZone()
+	record.Retroactive = !SameDay(record.TakenAt, now)
+
+	profileID, err := s.profileID()
+	if err != nil {
+		return err
+	}
+	record.ProfileID = profileID
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var existing []PresenceRecord
+		if err := tx.Where("profile_id = ? AND day = ?", profileID, record.Date()).
+			Order("slot").
+			Find(&existing).Error; err != nil {
+			return fmt.Errorf("erro ao verificar registros do dia: %w", err)
+		}
+
+		record.Slot = 0
+		if len(existing) > 0 {
+			switch policy {
+			case ConflictReplace:
+				if err := tx.Delete(&existing).Error; err != nil {
+					return fmt.Errorf("erro ao substituir registro: %w", err)
+				}
+				// the new record takes over the slot, so it continues its revisions
+				record.Revision = nextRevision(existing, 0)
+			case ConflictSplit:
+				record.Slot = existing[len(existing)-1].Slot + 1
+			default:
+				return fmt.Errorf("%w (%s)", ErrDayRecorded, existing[0].Response)
+			}
+		}
+
+		return tx.Create(record).Error
+	})
+}
+
+// nextRevision returns the revision a record taking the given slot over from
+// the existing records of its day starts at
+func nextRevision(existing []PresenceRecord, slot int) int {
+	for _, r := range existing {
+		if r.Slot == slot {
+			return r.Revision + 1
+		}
+	}
+	return 0
+}
+
+// UpdateRecord stores the response, area and observation of an existing
+// record and advances its revision
+func (s *SQLite) UpdateRecord(record *PresenceRecord) error {
+	result := s.db.Model(&PresenceRecord{ID: record.ID}).
+		Updates(map[string]any{
+			"response":    record.Response,
+			"area":        record.Area,
+			"observation": record.Observation,
+			"revision":    gorm.Expr("revision + 1"),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("erro ao atualizar registro: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("registro %d não encontrado", record.ID)
+	}
+	return nil
+}
+
+// DeleteRecord removes the record with the given ID
+func (s *SQLite) DeleteRecord(id uint) error {
+	if err := s.db.Delete(&PresenceRecord{}, id).Error; err != nil {
+		return fmt.Errorf("erro ao excluir registro: %w", err)
+	}
+	return nil
+}
+
+// ApplyImport stores the records of an import plan in a single transaction
+// according to the action of each item and returns how many were written.
+// The days of items that take the imported record are cleared once, before
+// anything is written, so every incoming record of such a day is kept.
+// Records always get a new ID and the next free slot of their day, and
+// continue the revisions of the record they replace in that slot
+func (s *SQLite) ApplyImport(plan ImportPlan) (int, error) {
+	profileID, err := s.profileID()
+	if err != nil {
+		return 0, err
+	}
+
+	var cleared []string
+	for _, item := range plan {
+		if day := item.Record.Date(); item.Action == ImportTakeImported && !slices.Contains(cleared, day) {
+			cleared = append(cleared, day)
+		}
+	}
+
+	imported := 0
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		replaced := map[string][]PresenceRecord{}
+		for _, day := range cleared {
+			var existing []PresenceRecord
+			if err := tx.Where("profile_id = ? AND day = ?", profileID, day).
+				Find(&existing).Error; err != nil {
+				return fmt.Errorf("erro ao verificar registros do dia: %w", err)
+			}
+			replaced[day] = existing
+
+			if err := tx.Where("profile_id = ? AND day = ?", profileID, day).
+				Delete(&PresenceRecord{}).Error; err != nil {
+				return fmt.Errorf("erro ao substituir registro: %w", err)
+			}
+		}
+
+		for _, item := range plan {
+			if item.Action == ImportKeepLocal {
+				continue
+			}
+
+			record := item.Record
+			record.ID = 0
+			record.ProfileID = profileID
+			day := record.Date()
+
+			var last PresenceRecord
+			result := tx.Where("profile_id = ? AND day = ?", profileID, day).
+				Order("slot DESC").
+				Limit(1).
+				Find(&last)
+			if result.Error != nil {
+				return fmt.Errorf("erro ao verificar registros do dia: %w", result.Error)
+			}
+
+			record.Slot = 0
+			if result.RowsAffected > 0 {
+				record.Slot = last.Slot + 1
+			}
+			record.Revision = nextRevision(replaced[day], record.Slot)
+			record.UpdatedAt = time.Time{}
+
+			if err := tx.Create(&record).Error; err != nil {
+				return fmt.Errorf("erro ao importar registro: %w", err)
+			}
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+// SplitDays returns the days that had several records when the database
+// was opened and were turned into split days by its migration
+func (s *SQLite) SplitDays() []string {
+	return s.splitDays
+}
+
+// Areas returns the configured work areas
+func (s *SQLite) Areas() ([]string, error) {
+	opts, err := s.interactionOptions(func(i *AppInteraction) string { return i.AreaOptions })
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar áreas: %w", err)
+	}
+	return opts.ValuesArea, nil
+}
+
+// SaveAreas replaces the configured work areas
+func (s *SQLite) SaveAreas(areas []string) error {
+	data, err := json.Marshal(options{ValuesArea: areas})
+	if err != nil {
+		return fmt.Errorf("erro ao serializar áreas: %w", err)
+	}
+	return s.updateInteraction("area_options", string(data))
+}
+
+// Headers returns the configured report headers
+func (s *SQLite) Headers() ([]string, error) {
+	opts, err := s.interactionOptions(func(i *AppInteraction) string { return i.Headers })
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar headers: %w", err)
+	}
+	return opts.ValuesHeaders, nil
+}
+
+// SaveHeaders replaces the configured report headers
+func (s *SQLite) SaveHeaders(headers []string) error {
+	data, err := json.Marshal(options{ValuesHeaders: headers})
+	if err != nil {
+		return fmt.Errorf("erro ao serializar headers: %w", err)
+	}
+	return s.updateInteraction("headers", string(data))
+}
+
+// ResponseTypes returns the response catalog in display order
+func (s *SQLite) ResponseTypes() ([]ResponseType, error) {
+	var types []ResponseType
+	if err := s.db.Order("position, id").Find(&types).Error; err != nil {
+		return nil, fmt.Errorf("erro ao carregar tipos de resposta: %w", err)
+	}
+	return types, nil
+}
+
+// SaveResponseTypes replaces the response catalog, renaming the records of
+// renamed types. Types still used by records cannot be removed
+func (s *SQLite) SaveResponseTypes(types []ResponseType) error {
+	names := map[string]bool{}
+	for _, t := range types {
+		if t.Name == "" || names[t.Name] {
+			return fmt.Errorf("%w: %q", ErrInvalidResponseType, t.Name)
+		}
+		names[t.Name] = true
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var current []ResponseType
+		if err := tx.Find(&current).Error; err != nil {
+			return fmt.Errorf("erro ao carregar tipos de resposta: %w", err)
+		}
+
+		kept := map[uint]bool{}
+		for _, t := range types {
+			kept[t.ID] = true
+		}
+
+		for _, old := range current {
+			if kept[old.ID] {
+				continue
+			}
+
+			var used int64
+			tx.Model(&PresenceRecord{}).Where("response = ?", old.Name).Count(&used)
+			if used > 0 {
+				return fmt.Errorf("%w: %s (%d)", ErrResponseTypeInUse, old.Name, used)
+			}
+
+			if err := tx.Delete(&old).Error; err != nil {
+				return fmt.Errorf("erro ao excluir tipo de resposta: %w", err)
+			}
+		}
+
+		// Names are unique and renames may swap names between entries, so both
+		// the catalog and the records are moved to a placeholder name first
+		renamed := map[uint]string{}
+		for _, old := range current {
+			for _, t := range types {
+				if t.ID == old.ID && t.Name != old.Name {
+					renamed[old.ID] = t.Name
+					placeholder := fmt.Sprintf("\x00%d", old.ID)
+
+					if err := tx.Model(&PresenceRecord{}).Where("response = ?", old.Name).
+						Update("response", placeholder).Error; err != nil {
+						return fmt.Errorf("erro ao renomear registros: %w", err)
+					}
+					if err := tx.Model(&old).Update("name", placeholder).Error; err != nil {
+						return fmt.Errorf("erro ao salvar tipo de resposta: %w", err)
+					}
+				}
+			}
+		}
+
+		for id, name := range renamed {
+			if err := tx.Model(&PresenceRecord{}).Where("response = ?", fmt.Sprintf("\x00%d", id)).
+				Updates(map[string]any{"response": name, "revision": gorm.Expr("revision + 1")}).Error; err != nil {
+				return fmt.Errorf("erro ao renomear registros: %w", err)
+			}
+		}
+
+		for i := range types {
+			types[i].Position = i
+			if err := tx.Save(&types[i]).Error; err != nil {
+				return fmt.Errorf("erro ao salvar tipo de resposta: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Holidays returns the national and stored holidays in [from, to), in date order
+func (s *SQLite) Holidays(from, to time.Time) ([]Holiday, error) {
+	var list []Holiday
+	if err := s.db.
+		Where("day >= ? AND day < ?", from.Format(LayoutISO), to.Format(LayoutISO)).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("erro ao carregar feriados: %w", err)
+	}
+
+	for _, h := range holidays.Between(StartOfDay(from), StartOfDay(to)) {
+		list = append(list, Holiday{Day: h.Date.Format(LayoutISO), Name: h.Name, Source: HolidayNational})
+	}
+
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Day < list[j].Day })
+	return list, nil
+}
+
+// SaveHoliday stores a user-defined holiday
+func (s *SQLite) SaveHoliday(holiday *Holiday) error {
+	if err := validateHoliday(holiday); err != nil {
+		return err
+	}
+	if holiday.Source == "" {
+		holiday.Source = HolidayManual
+	}
+
+	if err := s.db.Save(holiday).Error; err != nil {
+		return fmt.Errorf("erro ao salvar feriado: %w", err)
+	}
+	return nil
+}
+
+// DeleteHoliday removes a stored holiday
+func (s *SQLite) DeleteHoliday(id uint) error {
+	if err := s.db.Delete(&Holiday{}, id).Error; err != nil {
+		return fmt.Errorf("erro ao excluir feriado: %w", err)
+	}
+	return nil
+}
+
+// ImportHolidays stores the holidays not yet known, returning how many were added
+func (s *SQLite) ImportHolidays(list []Holiday) (int, error) {
+	added := 0
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, h := range list {
+			if err := validateHoliday(&h); err != nil {
+				return err
+			}
+
+			var count int64
+			tx.Model(&Holiday{}).Where("day = ? AND name = ?", h.Day, h.Name).Count(&count)
+			if count > 0 {
+				continue
+			}
+
+			h.ID = 0
+			if h.Source == "" {
+				h.Source = HolidayImported
+			}
+			if err := tx.Create(&h).Error; err != nil {
+				return fmt.Errorf("erro ao importar feriado: %w", err)
+			}
+			added++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// Config returns the application settings
+func (s *SQLite) Config() (*AppConfig, error) {
+	app, err := s.LoadApp()
+	if err != nil {
+		return nil, err
+	}
+	return &app.AppConfig, nil
+}
+
+// SaveGoal validates and stores the goal, its mode and its period
+func (s *SQLite) SaveGoal(goal int, mode GoalMode, period GoalPeriod) error {
+	if err := ValidateGoal(goal, mode, period); err != nil {
+		return err
+	}
+
+	cfg, err := s.Config()
+	if err != nil {
+		return err
+	}
+
+	cfg.DefaultGoal = goal
+	cfg.GoalMode = mode
+	cfg.GoalPeriod = period
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(cfg).Error; err != nil {
+			return err
+		}
+
+		// Changing the goal twice on the same day keeps only the last one
+		change := goalChange(cfg, time.Now())
+		return tx.Where(GoalChange{Since: change.Since}).
+			Assign(GoalChange{DefaultGoal: goal, GoalMode: mode, GoalPeriod: period}).
+			FirstOrCreate(change).Error
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao salvar config: %w", err)
+	}
+	return nil
+}
+
+// GoalAt returns the goal settings in force on day. Days before the first
+// recorded change use the oldest known goal
+func (s *SQLite) GoalAt(day time.Time) (*AppConfig, error) {
+	var changes []GoalChange
+	err := s.db.Where("since <= ?", day.Format(LayoutISO)).Order("since DESC").Limit(1).Find(&changes).Error
+	if err == nil && len(changes) == 0 {
+		err = s.db.Order("since").Limit(1).Find(&changes).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar histórico de metas: %w", err)
+	}
+
+	if len(changes) == 0 {
+		return s.Config()
+	}
+
+	cfg := changes[0].Config()
+	return &cfg, nil
+}
+
+// SaveCalendarView stores whether the main window shows the calendar grid
+func (s *SQLite) SaveCalendarView(enabled bool) error {
+	cfg, err := s.Config()
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Model(cfg).Update("calendar_view", enabled).Error; err != nil {
+		return fmt.Errorf("erro ao salvar config: %w", err)
+	}
+	return nil
+}
+
+// ReportTemplates returns the user-defined report templates ordered by name
+func (s *SQLite) ReportTemplates() ([]ReportTemplate, error) {
+	var templates []ReportTemplate
+	if err := s.db.Order("name").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("erro ao carregar modelos de relatório: %w", err)
+	}
+	return templates, nil
+}
+
+// SaveReportTemplate validates and stores a report template
+func (s *SQLite) SaveReportTemplate(tpl *ReportTemplate) error {
+	if err := ValidateTemplate(tpl); err != nil {
+		return err
+	}
+
+	var count int64
+	s.db.Model(&ReportTemplate{}).Where("name = ? AND id <> ?", tpl.Name, tpl.ID).Count(&count)
+	if count > 0 {
+		return fmt.Errorf("%w: nome %q repetido", ErrInvalidTemplate, tpl.Name)
+	}
+
+	if err := s.db.Save(tpl).Error; err != nil {
+		return fmt.Errorf("erro ao salvar modelo de relatório: %w", err)
+	}
+	return nil
+}
+
+// DeleteReportTemplate removes a report template, falling back to the
+// built-in report when the main window used it
+func (s *SQLite) DeleteReportTemplate(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&AppConfig{}).Where("main_template_id = ?", id).
+			Update("main_template_id", 0).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&ReportTemplate{}, id).Error
+	})
+}
+
+// SaveMainTemplate selects the report template of the main window, zero for the built-in report
+func (s *SQLite) SaveMainTemplate(id uint) error {
+	cfg, err := s.Config()
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Model(cfg).Update("main_template_id", id).Error; err != nil {
+		return fmt.Errorf("erro ao salvar config: %w", err)
+	}
+	return nil
+}
+
+// CalendarRules returns the calendar import rules in the order they are tried
+func (s *SQLite) CalendarRules() ([]CalendarRule, error) {
+	var rules []CalendarRule
+	if err := s.db.Order("position, id").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("erro ao carregar regras de importação: %w", err)
+	}
+	return rules, nil
+}
+
+// SaveCalendarRules validates and replaces the calendar import rules, keeping
+// them in the given order
+func (s *SQLite) SaveCalendarRules(rules []CalendarRule) error {
+	for i := range rules {
+		if err := ValidateCalendarRule(&rules[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&CalendarRule{}).Error; err != nil {
+			return fmt.Errorf("erro ao salvar regras de importação: %w", err)
+		}
+
+		for i := range rules {
+			rules[i].ID = 0
+			rules[i].Position = i
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		if err := tx.Create(&rules).Error; err != nil {
+			return fmt.Errorf("erro ao salvar regras de importação: %w", err)
+		}
+		return nil
+	})
+}
+
+// SaveBackupInterval stores the hours between automatic snapshots, negative to disable them
+func (s *SQLite) SaveBackupInterval(hours int) error {
+	cfg, err := s.Config()
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Model(cfg).Update("backup_interval", hours).Error; err != nil {
+		return fmt.Errorf("erro ao salvar config: %w", err)
+	}
+	return nil
+}
+
+// Snapshot writes a consistent copy of the database to path
+func (s *SQLite) Snapshot(path string) error {
+	if err := s.db.Exec("VACUUM INTO ?", path).Error; err != nil {
+		return fmt.Errorf("erro ao criar backup: %w", err)
+	}
+	return nil
+}
+
+// Backup returns the full content of the database
+func (s *SQLite) Backup() (*Backup, error) {
+	app, err := s.LoadApp()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Backup{
+		Format:    backupFormat,
+		Version:   BackupVersion,
+		CreatedAt: time.Now(),
+		App:       *app,
+	}
+
+	if err := s.db.Model(&SchemaVersion{}).Select("COALESCE(MAX(version), 0)").Scan(&b.SchemaVersion).Error; err != nil {
+		return nil, fmt.Errorf("erro ao ler versão do banco de dados: %w", err)
+	}
+
+	if b.Records, err = s.Records(); err != nil {
+		return nil, err
+	}
+	if b.ResponseTypes, err = s.ResponseTypes(); err != nil {
+		return nil, err
+	}
+	if b.ReportTemplates, err = s.ReportTemplates(); err != nil {
+		return nil, err
+	}
+	if b.CalendarRules, err = s.CalendarRules(); err != nil {
+		return nil, err
+	}
+
+	for _, q := range []struct {
+		dest  any
+		order string
+	}{
+		{&b.Holidays, "day, id"},
+		{&b.GoalChanges, "since"},
+	} {
+		if err := s.db.Order(q.order).Find(q.dest).Error; err != nil {
+			return nil, fmt.Errorf("erro ao ler dados para backup: %w", err)
+		}
+	}
+
+	return b, nil
+}
+
+// Restore replaces the whole content of the database with a backup in a
+// single transaction. The backup is validated before anything is deleted
+func (s *SQLite) Restore(b *Backup) error {
+	if err := ValidateBackup(b); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		all := tx.Session(&gorm.Session{AllowGlobalUpdate: true})
+		for _, model := range []any{
+			&PresenceRecord{}, &GoalChange{}, &ReportTemplate{}, &CalendarRule{}, &Holiday{}, &ResponseType{},
+			&App{}, &AppLanguage{}, &AppInteraction{}, &AppConfig{},
+		} {
+			if err := all.Delete(model).Error; err != nil {
+				return fmt.Errorf("erro ao limpar dados antes da restauração: %w", err)
+			}
+		}
+
+		app := b.App
+		if err := tx.Create(&app).Error; err != nil {
+			return fmt.Errorf("erro ao restaurar dados do app: %w", err)
+		}
+
+		records := make([]PresenceRecord, len(b.Records))
+		for i, r := range b.Records {
+			r.ProfileID = app.ID
+			records[i] = r
+		}
+
+		for _, rows := range []struct {
+			name string
+			data any
+			n    int
+		}{
+			{"registros", &records, len(records)},
+			{"tipos de resposta", &b.ResponseTypes, len(b.ResponseTypes)},
+			{"feriados", &b.Holidays, len(b.Holidays)},
+			{"histórico de metas", &b.GoalChanges, len(b.GoalChanges)},
+			{"modelos de relatório", &b.ReportTemplates, len(b.ReportTemplates)},
+			{"regras de importação", &b.CalendarRules, len(b.CalendarRules)},
+		} {
+			if rows.n == 0 {
+				continue
+			}
+			if err := tx.CreateInBatches(rows.data, 100).Error; err != nil {
+				return fmt.Errorf("erro ao restaurar %s: %w", rows.name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Close releases the underlying database connection
+func (s *SQLite) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// profileID returns the ID of the application profile records belong to
+func (s *SQLite) profileID() (uint, error) {
+	var app App
+	if err := s.db.Select("id").Order("id").First(&app).Error; err != nil {
+		return 0, fmt.Errorf("erro ao carregar dados do app: %w", err)
+	}
+	return app.ID, nil
+}
+
+func (s *SQLite) interactionOptions(field func(*AppInteraction) string) (options, error) {
+	var opts options
+
+	app, err := s.LoadApp()
+	if err != nil {
+		return opts, err
+	}
+
+	if err := json.Unmarshal([]byte(field(&app.Interaction)), &opts); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+func (s *SQLite) updateInteraction(column, value string) error {
+	app, err := s.LoadApp()
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Model(&app.Interaction).Update(column, value).Error; err != nil {
+		return fmt.Errorf("erro ao salvar no banco de dados: %w", err)
+	}
+	return nil
+}
+
+// goalChange returns the history entry of cfg taking effect on day
+func goalChange(cfg *AppConfig, day time.Time) *GoalChange {
+	return &GoalChange{
+		Since:       day.Format(LayoutISO),
+		DefaultGoal: cfg.DefaultGoal,
+		GoalMode:    cfg.Mode(),
+		GoalPeriod:  cfg.Period(),
+	}
+}
+
+func validateHoliday(h *Holiday) error {
+	h.Name = strings.TrimSpace(h.Name)
+	if h.Name == "" {
+		return fmt.Errorf("%w: nome ausente", ErrInvalidHoliday)
+	}
+	if _, err := time.Parse(LayoutISO, h.Day); err != nil {
+		return fmt.Errorf("%w: data %q", ErrInvalidHoliday, h.Day)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// useSaoPaulo runs the test with America/Sao_Paulo as the local time zone
+func useSaoPaulo(t *testing.T) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("fuso horário indisponível: %v", err)
+	}
+
+	t.Setenv("TZ", "America/Sao_Paulo")
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+	return loc
+}
+
+// newTestStore opens a migrated database with the default application data
+func newTestStore(t *testing.T) *SQLite {
+	t.Helper()
+
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "presencial.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	if _, err := s.CreateDefaultApp(); err != nil {
+		t.Fatalf("CreateDefaultApp: %v", err)
+	}
+	return s
+}
+
+// saveDay stores the responses as the records of a day, one slot each
+func saveDay(t *testing.T, s *SQLite, day time.Time, responses ...string) {
+	t.Helper()
+
+	for i, response := range responses {
+		policy := ConflictSplit
+		if i == 0 {
+			policy = ConflictReject
+		}
+		r := &PresenceRecord{TakenAt: day.Add(time.Duration(i) * time.Minute), Response: response, Area: "CT"}
+		if err := s.SaveRecord(r, policy); err != nil {
+			t.Fatalf("SaveRecord(%s): %v", response, err)
+		}
+	}
+}
+
+// dayRecords returns the records of the day of t in slot order
+func dayRecords(t *testing.T, s *SQLite, day time.Time) []PresenceRecord {
+	t.Helper()
+
+	records, err := s.RecordsBetween(StartOfDay(day), StartOfDay(day).AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("RecordsBetween: %v", err)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Slot < records[j].Slot })
+	return records
+}
+
+func responses(records []PresenceRecord) []string {
+	list := []string{}
+	for _, r := range records {
+		list = append(list, r.Response)
+	}
+	return list
+}
+
+func TestSaveRecordPolicies(t *testing.T) {
+	loc := useSaoPaulo(t)
+	day := time.Date(2026, time.March, 10, 9, 0, 0, 0, loc)
+
+	tests := []struct {
+		name     string
+		existing []string
+		policy   ConflictPolicy
+		wantErr  error
+		want     []string
+		// wantRevision is the revision of the record in slot 0
+		wantRevision int
+	}{
+		{"reject on a free day", nil, ConflictReject, nil, []string{"Remoto"}, 0},
+		{"reject on a recorded day", []string{"Presencial"}, ConflictReject, ErrDayRecorded, []string{"Presencial"}, 0},
+		{"split on a free day", nil, ConflictSplit, nil, []string{"Remoto"}, 0},
+		{"split on a recorded day", []string{"Presencial"}, ConflictSplit, nil, []string{"Presencial", "Remoto"}, 0},
+		{"split on a split day", []string{"Presencial", "Férias"}, ConflictSplit, nil, []string{"Presencial", "Férias", "Remoto"}, 0},
+		{"replace on a free day", nil, ConflictReplace, nil, []string{"Remoto"}, 0},
+		{"replace on a recorded day", []string{"Presencial"}, ConflictReplace, nil, []string{"Remoto"}, 1},
+		{"replace on a split day", []string{"Presencial", "Férias"}, ConflictReplace, nil, []string{"Remoto"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			saveDay(t, s, day, tt.existing...)
+
+			err := s.SaveRecord(&PresenceRecord{TakenAt: day.Add(time.Hour), Response: "Remoto"}, tt.policy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SaveRecord error = %v, want %v", err, tt.wantErr)
+			}
+
+			records := dayRecords(t, s, day)
+			if got := responses(records); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("records = %v, want %v", got, tt.want)
+			}
+			for i, r := range records {
+				if r.Slot != i {
+					t.Errorf("record %s in slot %d, want %d", r.Response, r.Slot, i)
+				}
+			}
+			if records[0].Revision != tt.wantRevision {
+				t.Errorf("revision = %d, want %d", records[0].Revision, tt.wantRevision)
+			}
+		})
+	}
+}
+
+func TestUpdateRecordRevision(t *testing.T) {
+	loc := useSaoPaulo(t)
+	s := newTestStore(t)
+	day := time.Date(2026, time.March, 10, 9, 0, 0, 0, loc)
+	saveDay(t, s, day, "Presencial")
+
+	for want := 1; want <= 2; want++ {
+		record := dayRecords(t, s, day)[0]
+		record.Observation = fmt.Sprintf("edição %d", want)
+		if err := s.UpdateRecord(&record); err != nil {
+			t.Fatalf("UpdateRecord: %v", err)
+		}
+
+		updated := dayRecords(t, s, day)[0]
+		if updated.Revision != want || updated.Observation != record.Observation {
+			t.Errorf("record = revision %d %q, want revision %d %q", updated.Revision, updated.Observation, want, record.Observation)
+		}
+		if updated.UpdatedAt.Before(updated.TakenAt) {
+			t.Errorf("updated at %v before taken at %v", updated.UpdatedAt, updated.TakenAt)
+		}
+	}
+}
+
+func TestRecordsBetweenMonthBoundaries(t *testing.T) {
+	loc := useSaoPaulo(t)
+	s := newTestStore(t)
+
+	// 23:30 in São Paulo is already the next day in UTC
+	for _, at := range []time.Time{
+		time.Date(2026, time.January, 31, 23, 30, 0, 0, loc),
+		time.Date(2026, time.February, 1, 0, 30, 0, 0, loc),
+		time.Date(2026, time.February, 28, 23, 59, 0, 0, loc),
+		time.Date(2026, time.March, 1, 0, 0, 0, 0, loc),
+		time.Date(2026, time.March, 31, 21, 30, 0, 0, loc),
+	} {
+		saveDay(t, s, at, "Presencial")
+	}
+
+	tests := []struct {
+		month time.Month
+		want  []string
+	}{
+		{time.January, []string{"2026-01-31 23:30"}},
+		{time.February, []string{"2026-02-28 23:59", "2026-02-01 00:30"}},
+		{time.March, []string{"2026-03-31 21:30", "2026-03-01 00:00"}},
+		{time.April, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.month.String(), func(t *testing.T) {
+			from := time.Date(2026, tt.month, 1, 0, 0, 0, 0, loc)
+			records, err := s.RecordsBetween(from, from.AddDate(0, 1, 0))
+			if err != nil {
+				t.Fatalf("RecordsBetween: %v", err)
+			}
+
+			got := []string{}
+			for _, r := range records {
+				got = append(got, r.Date()+" "+r.Local().Format("15:04"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Package store provides the persistence layer for the attendance tracking application
+package store
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// LayoutISO is the date layout used for record days
+	LayoutISO = "2006-01-02"
+
+	layoutTime = "15:04:05"
+	layoutBR   = "02/01/2006"
+)
+
+var (
+	// ErrInvalidGoal is returned when the monthly goal is outside the accepted range
+	ErrInvalidGoal = errors.New("valores inválidos")
+	// ErrResponseTypeInUse is returned when removing a response type that records still use
+	ErrResponseTypeInUse = errors.New("tipo de resposta em uso por registros")
+	// ErrInvalidResponseType is returned when a response type has no name or a duplicated one
+	ErrInvalidResponseType = errors.New("tipo de resposta inválido")
+	// ErrInvalidHoliday is returned when a holiday has no name or an invalid day
+	ErrInvalidHoliday = errors.New("feriado inválido")
+	// ErrInvalidTemplate is returned when a report template has no name, a duplicated one or does not parse
+	ErrInvalidTemplate = errors.New("modelo de relatório inválido")
+	// ErrInvalidCalendarRule is returned when a calendar import rule is incomplete or its regular expression does not compile
+	ErrInvalidCalendarRule = errors.New("regra de importação de calendário inválida")
+)
+
+// Repository abstracts the persistence of records, areas and configuration
+// so the data layer can be used without a graphical front end
+type Repository interface {
+	// LoadApp returns the application entity with its language, interaction and config
+	LoadApp() (*App, error)
+	// CreateDefaultApp seeds the database with the default application data
+	CreateDefaultApp() (*App, error)
+
+	// Records returns every presence record, newest first
+	Records() ([]PresenceRecord, error)
+	// RecordsBetween returns the records taken in [from, to), newest first
+	RecordsBetween(from, to time.Time) ([]PresenceRecord, error)
+	// SaveRecord stores a new presence record. Records without a timestamp are
+	// stamped with the current time, records for another day are flagged
+	// retroactive. The policy decides what happens when the day already has a record
+	SaveRecord(record *PresenceRecord, policy ConflictPolicy) error
+	// UpdateRecord stores the response, area and observation of an existing record
+	UpdateRecord(record *PresenceRecord) error
+	// DeleteRecord removes the record with the given ID
+	DeleteRecord(id uint) error
+	// ApplyImport stores the records of an import plan in a single transaction
+	// according to the action of each item, returning how many were written
+	ApplyImport(plan ImportPlan) (int, error)
+	// SplitDays returns the days that had several records when the database
+	// was opened and were turned into split days by its migration
+	SplitDays() []string
+
+	// Areas returns the configured work areas
+	Areas() ([]string, error)
+	// SaveAreas replaces the configured work areas
+	SaveAreas(areas []string) error
+	// Headers returns the configured report headers
+	Headers() ([]string, error)
+	// SaveHeaders replaces the configured report headers
+	SaveHeaders(headers []string) error
+
+	// ResponseTypes returns the response catalog in display order
+	ResponseTypes() ([]ResponseType, error)
+	// SaveResponseTypes replaces the response catalog, renaming the records of
+	// renamed types. Types still used by records cannot be removed
+	SaveResponseTypes(types []ResponseType) error
+
+	// Holidays returns the national and stored holidays in [from, to), in date order
+	Holidays(from, to time.Time) ([]Holiday, error)
+	// SaveHoliday stores a user-defined holiday
+	SaveHoliday(holiday *Holiday) error
+	// DeleteHoliday removes a stored holiday
+	DeleteHoliday(id uint) error
+	// ImportHolidays stores the holidays not yet known, returning how many were added
+	ImportHolidays(holidays []Holiday) (int, error)
+
+	// Config returns the application settings
+	Config() (*AppConfig, error)
+	// SaveGoal validates and stores the goal, its mode and its period. The new
+	// goal applies from today on, earlier periods keep the goal they had
+	SaveGoal(goal int, mode GoalMode, period GoalPeriod) error
+	// GoalAt returns the goal settings in force on day
+	GoalAt(day time.Time) (*AppConfig, error)
+	// SaveCalendarView stores whether the main window shows the calendar grid
+	SaveCalendarView(enabled bool) error
+
+	// ReportTemplates returns the user-defined report templates ordered by name
+	ReportTemplates() ([]ReportTemplate, error)
+	// SaveReportTemplate validates and stores a report template
+	SaveReportTemplate(tpl *ReportTemplate) error
+	// DeleteReportTemplate removes a report template, falling back to the
+	// built-in report when the main window used it
+	DeleteReportTemplate(id uint) error
+	// SaveMainTemplate selects the report template of the main window, zero for the built-in report
+	SaveMainTemplate(id uint) error
+
+	// CalendarRules returns the calendar import rules in the order they are tried
+	CalendarRules() ([]CalendarRule, error)
+	// SaveCalendarRules validates and replaces the calendar import rules
+	SaveCalendarRules(rules []CalendarRule) error
+
+	// SaveBackupInterval stores the hours between automatic snapshots, negative to disable them
+	SaveBackupInterval(hours int) error
+	// Snapshot writes a consistent copy of the database to path
+	Snapshot(path string) error
+
+	// Backup returns the full content of the database
+	Backup() (*Backup, error)
+	// Restore validates a backup and replaces the whole content of the
+	// database with it in a single transaction
+	Restore(b *Backup) error
+
+	// Close releases the underlying database connection
+	Close() error
+}
+
+// Holiday sources
+const (
+	HolidayNational = "nacional"
+	HolidayManual   = "manual"
+	HolidayImported = "ics"
+)
+
+// ConflictPolicy decides what SaveRecord does when the day already has a record
+type ConflictPolicy int
+
+const (
+	// ConflictReject refuses the new record with ErrDayRecorded
+	ConflictReject ConflictPolicy = iota
+	// ConflictReplace deletes the existing records of the day before saving
+	ConflictReplace
+	// ConflictSplit keeps the existing records and saves the new one in the next slot
+	ConflictSplit
+)
+
+// options mirrors the JSON documents stored in AppInteraction
+type options struct {
+	ValuesArea    []string `json:"areas,omitempty"`
+	ValuesHeaders []string `json:"headers,omitempty"`
+}
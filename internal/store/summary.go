@@ -0,0 +1,204 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// PeriodSummary aggregates the records of the period [From, To)
+type PeriodSummary struct {
+	Label      string
+	From       time.Time
+	To         time.Time
+	Records    int
+	ByArea     map[string]int
+	ByResponse map[string]int
+	// GoalDays counts the distinct days whose response counts toward the goal
+	GoalDays int
+	// Target is the number of office days the goals in force required
+	Target int
+}
+
+// Quarters returns the four quarters of year followed by the whole year
+func Quarters(year int, loc *time.Location) []PeriodSummary {
+	periods := make([]PeriodSummary, 0, 5)
+	for q := range 4 {
+		from := time.Date(year, time.Month(q*3+1), 1, 0, 0, 0, 0, loc)
+		periods = append(periods, PeriodSummary{
+			Label: fmt.Sprintf("%dº tri %d", q+1, year),
+			From:  from,
+			To:    from.AddDate(0, 3, 0),
+		})
+	}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	return append(periods, PeriodSummary{
+		Label: strconv.Itoa(year),
+		From:  from,
+		To:    from.AddDate(1, 0, 0),
+	})
+}
+
+// Months returns every calendar month from the month of from to the month of to
+func Months(from, to time.Time) []PeriodSummary {
+	var periods []PeriodSummary
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+	for !month.After(to) {
+		periods = append(periods, PeriodSummary{
+			Label: MonthTitle(month),
+			From:  month,
+			To:    month.AddDate(0, 1, 0),
+		})
+		month = month.AddDate(0, 1, 0)
+	}
+	return periods
+}
+
+// Summarize fills the counts and the goal target of each period
+func Summarize(repo Repository, periods []PeriodSummary) ([]PeriodSummary, error) {
+	types, err := repo.ResponseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range periods {
+		p := &periods[i]
+
+		records, err := repo.RecordsBetween(p.From, p.To)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Records = len(records)
+		p.ByArea = map[string]int{}
+		p.ByResponse = map[string]int{}
+		for _, r := range records {
+			p.ByResponse[r.Response]++
+			if r.Area != "" {
+				p.ByArea[r.Area]++
+			}
+		}
+		p.GoalDays = CountGoalDays(records, types)
+
+		if p.Target, err = TargetBetween(repo, p.From, p.To); err != nil {
+			return nil, err
+		}
+	}
+	return periods, nil
+}
+
+// TargetBetween sums the office days required in [from, to) by the goals in
+// force over it. Goal periods only partly inside the range are prorated by
+// their working days
+func TargetBetween(repo Repository, from, to time.Time) (int, error) {
+	var target float64
+	for cursor := from; cursor.Before(to); {
+		cfg, err := repo.GoalAt(cursor)
+		if err != nil {
+			return 0, err
+		}
+
+		pFrom, pTo := cfg.Period().Bounds(cursor)
+		hs, err := repo.Holidays(pFrom, pTo)
+		if err != nil {
+			return 0, err
+		}
+
+		full := cfg.Target(pFrom, pTo, hs)
+		segFrom, segTo := maxTime(pFrom, from), minTime(pTo, to)
+		if segFrom.Equal(pFrom) && segTo.Equal(pTo) {
+			target += float64(full)
+		} else if working := len(WorkingDays(pFrom, pTo, hs)); working > 0 {
+			target += float64(full) * float64(len(WorkingDays(segFrom, segTo, hs))) / float64(working)
+		}
+		cursor = pTo
+	}
+	return int(math.Round(target)), nil
+}
+
+// WeeklyAverage returns the goal days per week over the elapsed part of the period
+func (p *PeriodSummary) WeeklyAverage() float64 {
+	end := minTime(p.To, StartOfDay(time.Now()).AddDate(0, 0, 1))
+	days := end.Sub(p.From).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return float64(p.GoalDays) / (days / 7)
+}
+
+// GoalPercent returns the share of the target already reached, in percent
+func (p *PeriodSummary) GoalPercent() float64 {
+	if p.Target == 0 {
+		return 100
+	}
+	return float64(p.GoalDays) * 100 / float64(p.Target)
+}
+
+// SummaryTable lays the summaries out as rows with a header row first. Areas
+// and response types follow the given order, with any value found only in the
+// records appended at the end
+func SummaryTable(summaries []PeriodSummary, areas []string, types []ResponseType) [][]string {
+	areas = slices.Clone(areas)
+	responses := make([]string, 0, len(types))
+	for _, t := range types {
+		responses = append(responses, t.Name)
+	}
+
+	for _, s := range summaries {
+		areas = appendMissing(areas, s.ByArea)
+		responses = appendMissing(responses, s.ByResponse)
+	}
+
+	header := []string{"Período"}
+	header = append(header, areas...)
+	header = append(header, responses...)
+	header = append(header, "Dias na meta", "Média/semana", "Meta", "% da meta")
+	rows := [][]string{header}
+
+	for _, s := range summaries {
+		row := []string{s.Label}
+		for _, a := range areas {
+			row = append(row, strconv.Itoa(s.ByArea[a]))
+		}
+		for _, r := range responses {
+			row = append(row, strconv.Itoa(s.ByResponse[r]))
+		}
+		row = append(row,
+			strconv.Itoa(s.GoalDays),
+			strconv.FormatFloat(s.WeeklyAverage(), 'f', 1, 64),
+			strconv.Itoa(s.Target),
+			fmt.Sprintf("%.0f%%", s.GoalPercent()),
+		)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// appendMissing appends the keys of counts not yet in list, in sorted order
+func appendMissing(list []string, counts map[string]int) []string {
+	var missing []string
+	for k := range counts {
+		if !slices.Contains(list, k) {
+			missing = append(missing, k)
+		}
+	}
+	slices.Sort(missing)
+	return append(list, missing...)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
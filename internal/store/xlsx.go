@@ -0,0 +1,118 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/dyammarcano/presencial/internal/xlsx"
+)
+
+// ExportXLSX writes the records of the months from the month of from to the
+// month of to to an Excel workbook. A summary sheet with the counts per area
+// and the goal status of each month comes first, followed by one sheet per
+// month whose columns follow the configured headers. It returns the number
+// of records written
+func ExportXLSX(repo Repository, filePath string, from, to time.Time) (int, error) {
+	headers, err := repo.Headers()
+	if err != nil {
+		return 0, err
+	}
+	if len(headers) == 0 {
+		return 0, fmt.Errorf("nenhum header configurado para exportação")
+	}
+
+	areas, err := repo.Areas()
+	if err != nil {
+		return 0, err
+	}
+
+	months, err := Summarize(repo, Months(from, to))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range months {
+		areas = appendMissing(areas, m.ByArea)
+	}
+
+	wb := &xlsx.Workbook{}
+	summary := wb.AddSheet("Resumo")
+
+	title := []xlsx.Cell{xlsx.Header("Mês")}
+	for _, a := range areas {
+		title = append(title, xlsx.Header(a))
+	}
+	title = append(title, xlsx.Header("Dias na meta"), xlsx.Header("Meta"), xlsx.Header("% da meta"), xlsx.Header("Situação"))
+	summary.Append(title...)
+	summary.Widths = []float64{20}
+
+	var total int
+	for _, m := range months {
+		row := []xlsx.Cell{xlsx.Text(m.Label)}
+		for _, a := range areas {
+			row = append(row, xlsx.Number(float64(m.ByArea[a])))
+		}
+
+		status := "Não atingida"
+		if m.GoalDays >= m.Target {
+			status = "Atingida"
+		}
+		row = append(row,
+			xlsx.Number(float64(m.GoalDays)),
+			xlsx.Number(float64(m.Target)),
+			xlsx.Number(float64(int(m.GoalPercent()+0.5))),
+			xlsx.Text(status),
+		)
+		summary.Append(row...)
+
+		records, err := repo.RecordsBetween(m.From, m.To)
+		if err != nil {
+			return 0, err
+		}
+		slices.Reverse(records)
+		total += len(records)
+
+		sheet := wb.AddSheet(m.Label)
+		head := make([]xlsx.Cell, len(headers))
+		for i, h := range headers {
+			head[i] = xlsx.Header(h)
+			sheet.Widths = append(sheet.Widths, 14)
+		}
+		sheet.Append(head...)
+
+		for _, r := range records {
+			cells := make([]xlsx.Cell, len(headers))
+			for i, h := range headers {
+				field, _ := HeaderField(h)
+				cells[i] = recordCell(&r, field)
+			}
+			sheet.Append(cells...)
+		}
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao criar arquivo: %w", err)
+	}
+	defer f.Close()
+
+	if err := wb.Write(f); err != nil {
+		return 0, fmt.Errorf("erro ao salvar arquivo: %w", err)
+	}
+	return total, f.Close()
+}
+
+// recordCell returns the spreadsheet cell of field for r, with typed date
+// and time cells
+func recordCell(r *PresenceRecord, field string) xlsx.Cell {
+	switch field {
+	case FieldDate:
+		return xlsx.Date(r.Local())
+	case FieldTime:
+		return xlsx.Time(r.Local())
+	default:
+		return xlsx.Text(recordValue(r, field, LayoutISO))
+	}
+}
@@ -2,7 +2,6 @@
 package main
 
 import (
-	"errors"
 	"log"
 
 	"github.com/dyammarcano/presencial/internal/program"
@@ -11,7 +10,7 @@ import (
 func main() {
 	app, err := program.NewMainApp("presencial")
 	if err != nil {
-		log.Fatal(errors.New("erro ao criar app"))
+		log.Fatalf("erro ao criar app: %v", err)
 	}
 
 	app.RunApp()